@@ -0,0 +1,198 @@
+package ibc
+
+import (
+	"context"
+	"fmt"
+	"net/mail"
+	"strings"
+	"time"
+)
+
+// AlertCondition is a condition the boiler can send its own alert e-mail for.
+type AlertCondition int
+
+// alertConditionNames are indexed by the bit number of the condition.
+var alertConditionNames = [...]string{"Lockout", "Soft Error", "Warning", "Low Water Pressure", "Sensor Fault", "Network Loss", "Cleaning Due"}
+
+// Alert Condition Constants, the bit each condition sets in BoilerAlertData.Conditions and Active.
+const (
+	AlertLockout          AlertCondition = 0x0001
+	AlertSoftError        AlertCondition = 0x0002
+	AlertWarning          AlertCondition = 0x0004
+	AlertLowWaterPressure AlertCondition = 0x0008
+	AlertSensorFault      AlertCondition = 0x0010
+	AlertNetworkLoss      AlertCondition = 0x0020
+	AlertCleaningDue      AlertCondition = 0x0040
+)
+
+func (a AlertCondition) String() string {
+	for i, name := range alertConditionNames {
+		if a == 1<<uint(i) {
+			return name
+		}
+	}
+	return fmt.Sprintf("Unknown Alert 0x%04X", int(a))
+}
+
+// AlertConditions is a list of alert conditions.
+type AlertConditions []AlertCondition
+
+func (ac AlertConditions) String() string {
+	if len(ac) == 0 {
+		return "None"
+	}
+	s := make([]string, len(ac))
+	for i, a := range ac {
+		s[i] = a.String()
+	}
+	return strings.Join(s, ", ")
+}
+
+// DecodeAlertConditions returns every condition set in the specified bitmask. Unknown bits are reported individually.
+func DecodeAlertConditions(mask int) AlertConditions {
+	var ac AlertConditions
+	for bit := 0; bit < 16; bit++ {
+		if mask&(1<<uint(bit)) != 0 {
+			ac = append(ac, AlertCondition(1<<uint(bit)))
+		}
+	}
+	return ac
+}
+
+// BoilerAlertData represents the data returned by the ReqAlertData request. It holds the boiler's own alert
+// configuration and its recent alerts.
+type BoilerAlertData struct {
+	//"rbid": 0
+	//"object_no": 31
+	Enabled    bool   `json:"Enabled"`
+	Email1     string `json:"Email1"`
+	Email2     string `json:"Email2"`
+	From       string `json:"From"`
+	SMTPServer string `json:"SMTPServer"`
+	SMTPPort   int    `json:"SMTPPort"`
+	// Conditions has a bit set for each AlertCondition the boiler sends alerts for.
+	Conditions int `json:"Conditions"`
+	// Active has a bit set for each AlertCondition currently active.
+	Active int           `json:"Active"`
+	Recent []AlertRecord `json:"Recent"`
+}
+
+// AlertRecord is an alert recently raised by the boiler.
+type AlertRecord struct {
+	Date      string         `json:"Date"`
+	Time      string         `json:"Time"`
+	Condition AlertCondition `json:"Condition"`
+	// Sent is false if the boiler was unable to send the alert e-mail.
+	Sent bool `json:"Sent"`
+}
+
+// AlertedConditions returns the conditions the boiler is configured to send alerts for.
+func (ad BoilerAlertData) AlertedConditions() AlertConditions {
+	return DecodeAlertConditions(ad.Conditions)
+}
+
+// ActiveAlerts returns the alert conditions currently active on the boiler.
+func (ad BoilerAlertData) ActiveAlerts() AlertConditions {
+	return DecodeAlertConditions(ad.Active)
+}
+
+// Contacts returns the configured alert e-mail addresses.
+func (ad BoilerAlertData) Contacts() []string {
+	var contacts []string
+	for _, email := range []string{ad.Email1, ad.Email2} {
+		if email = strings.TrimSpace(email); email != "" {
+			contacts = append(contacts, email)
+		}
+	}
+	return contacts
+}
+
+// CheckContacts returns a description of each problem that would stop the boiler from delivering its alerts,
+// or that differs from the expected contact addresses if any are given. It returns nil if no problems are found.
+func (ad BoilerAlertData) CheckContacts(expected ...string) []string {
+	var problems []string
+	if !ad.Enabled {
+		problems = append(problems, "alerts are disabled")
+	}
+	if ad.Conditions == 0 {
+		problems = append(problems, "no alert conditions are selected")
+	}
+
+	contacts := ad.Contacts()
+	if len(contacts) == 0 {
+		problems = append(problems, "no alert e-mail address is configured")
+	}
+	for _, email := range append([]string{ad.From}, contacts...) {
+		if email == "" {
+			continue
+		}
+		if _, err := mail.ParseAddress(email); err != nil {
+			problems = append(problems, fmt.Sprintf("%q is not a valid e-mail address", email))
+		}
+	}
+	if ad.From == "" {
+		problems = append(problems, "no sender address is configured")
+	}
+	if strings.TrimSpace(ad.SMTPServer) == "" {
+		problems = append(problems, "no SMTP server is configured")
+	}
+	if ad.SMTPPort < 1 || ad.SMTPPort > 65535 {
+		problems = append(problems, fmt.Sprintf("SMTP port %d is invalid", ad.SMTPPort))
+	}
+
+	for _, want := range expected {
+		if !containsFold(contacts, want) {
+			problems = append(problems, fmt.Sprintf("expected contact %s is not configured", want))
+		}
+	}
+	if len(expected) > 0 {
+		for _, got := range contacts {
+			if !containsFold(expected, got) {
+				problems = append(problems, fmt.Sprintf("unexpected contact %s is configured", got))
+			}
+		}
+	}
+	return problems
+}
+
+func containsFold(list []string, s string) bool {
+	for _, item := range list {
+		if strings.EqualFold(strings.TrimSpace(item), strings.TrimSpace(s)) {
+			return true
+		}
+	}
+	return false
+}
+
+// Alert is a parsed AlertRecord.
+type Alert struct {
+	// Time is when the alert was raised, according to the boiler clock. It is zero if the boiler's date could not be parsed.
+	Time      time.Time
+	Condition AlertCondition
+	Sent      bool
+}
+
+// RecentAlerts returns the boiler's recent alerts with their times parsed in the specified location, or time.Local if nil.
+func (ad BoilerAlertData) RecentAlerts(loc *time.Location) []Alert {
+	if loc == nil {
+		loc = time.Local
+	}
+	alerts := make([]Alert, len(ad.Recent))
+	for i, r := range ad.Recent {
+		t, _ := ParseBoilerTime(r.Date, r.Time, loc)
+		alerts[i] = Alert{Time: t, Condition: r.Condition, Sent: r.Sent}
+	}
+	return alerts
+}
+
+// GetAlertData returns the BoilerAlertData for the current boiler.
+func (b Boiler) GetAlertData() (BoilerAlertData, error) {
+	return b.GetAlertDataContext(context.Background())
+}
+
+// GetAlertDataContext returns the BoilerAlertData for the current boiler using the provided context.
+func (b Boiler) GetAlertDataContext(ctx context.Context) (BoilerAlertData, error) {
+	reqObj := requestObject{ObjectNum: 100, ObjectRequest: ReqAlertData, BoilerNum: b.BoilerNum}
+	var respObj = BoilerAlertData{}
+	return respObj, b.getData(ctx, reqObj, &respObj)
+}
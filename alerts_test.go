@@ -0,0 +1,66 @@
+package ibc
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestBoilerAlertDataGolden(t *testing.T) {
+	var ad BoilerAlertData
+	testGolden(t, "alert_data", &ad)
+}
+
+func TestGetAlertData(t *testing.T) {
+	b, done := newFixtureBoiler(t, map[int]string{ReqAlertData: "alert_data.json"})
+	defer done()
+
+	ad, err := b.GetAlertData()
+	if err != nil {
+		t.Fatalf("GetAlertData returned error: %v", err)
+	}
+	if got := ad.AlertedConditions().String(); got != "Lockout, Soft Error, Low Water Pressure" {
+		t.Errorf("AlertedConditions is incorrect, got: %v.", got)
+	}
+	if got := ad.ActiveAlerts(); len(got) != 1 || got[0] != AlertLowWaterPressure {
+		t.Errorf("ActiveAlerts is incorrect, got: %v.", got)
+	}
+
+	alerts := ad.RecentAlerts(time.UTC)
+	want := Alert{Time: time.Date(2018, time.October, 28, 19, 40, 0, 0, time.UTC), Condition: AlertLockout, Sent: false}
+	if len(alerts) != 2 || alerts[1] != want {
+		t.Errorf("RecentAlerts is incorrect, got: %+v, want: %+v.", alerts, want)
+	}
+}
+
+func TestDecodeAlertConditions(t *testing.T) {
+	got := DecodeAlertConditions(0x0120)
+	if len(got) != 2 || got[0] != AlertNetworkLoss || got[1].String() != "Unknown Alert 0x0100" {
+		t.Errorf("DecodeAlertConditions is incorrect, got: %v.", got)
+	}
+	if got := DecodeAlertConditions(0).String(); got != "None" {
+		t.Errorf("DecodeAlertConditions of no alerts is incorrect, got: %v.", got)
+	}
+}
+
+func TestCheckContacts(t *testing.T) {
+	ad := BoilerAlertData{Enabled: true, Email1: "service@example.com", From: "boiler@example.com", SMTPServer: "smtp.example.com", SMTPPort: 25, Conditions: 1}
+	if problems := ad.CheckContacts(); problems != nil {
+		t.Errorf("CheckContacts of a valid configuration is incorrect, got: %v.", problems)
+	}
+	if problems := ad.CheckContacts("Service@Example.com"); problems != nil {
+		t.Errorf("CheckContacts with the expected contact is incorrect, got: %v.", problems)
+	}
+
+	want := []string{"expected contact owner@example.com is not configured", "unexpected contact service@example.com is configured"}
+	if problems := ad.CheckContacts("owner@example.com"); !reflect.DeepEqual(problems, want) {
+		t.Errorf("CheckContacts with a different contact is incorrect, got: %v, want: %v.", problems, want)
+	}
+
+	bad := BoilerAlertData{Email2: "not an address"}
+	want = []string{"alerts are disabled", "no alert conditions are selected", `"not an address" is not a valid e-mail address`,
+		"no sender address is configured", "no SMTP server is configured", "SMTP port 0 is invalid"}
+	if problems := bad.CheckContacts(); !reflect.DeepEqual(problems, want) {
+		t.Errorf("CheckContacts of an invalid configuration is incorrect, got: %v, want: %v.", problems, want)
+	}
+}
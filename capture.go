@@ -0,0 +1,156 @@
+package ibc
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// BoilerRunProfileData represents the data returned by the ReqBoilerRunProfileData request.
+// It describes the firing sequence the burner follows on each ignition.
+type BoilerRunProfileData struct {
+	//"rbid": 0
+	//"object_no": 5
+	// PrePurge, Ignition and PostPurge are the lengths of each stage of the sequence, in seconds.
+	PrePurge  int `json:"PrePurge"`
+	Ignition  int `json:"IgnTime"`
+	PostPurge int `json:"PostPurge"`
+	// The fan speeds used for purging, ignition and the modulation range, in RPM.
+	PurgeRPM    int `json:"PurgeRPM"`
+	IgnitionRPM int `json:"IgnRPM"`
+	MinRPM      int `json:"MinRPM"`
+	MaxRPM      int `json:"MaxRPM"`
+	// Trials is the number of ignition trials before the boiler locks out.
+	Trials int `json:"Trials"`
+}
+
+// GetBoilerRunProfileData returns the BoilerRunProfileData for the current boiler.
+func (b Boiler) GetBoilerRunProfileData() (BoilerRunProfileData, error) {
+	return b.GetBoilerRunProfileDataContext(context.Background())
+}
+
+// GetBoilerRunProfileDataContext returns the BoilerRunProfileData for the current boiler using the provided context.
+func (b Boiler) GetBoilerRunProfileDataContext(ctx context.Context) (BoilerRunProfileData, error) {
+	reqObj := requestObject{ObjectNum: 100, ObjectRequest: ReqBoilerRunProfileData, BoilerNum: b.BoilerNum}
+	var respObj = BoilerRunProfileData{}
+	return respObj, b.getData(ctx, reqObj, &respObj)
+}
+
+// CaptureTrigger is the event that caused the boiler to capture a snapshot.
+type CaptureTrigger int
+
+// Capture Trigger Constants
+const (
+	TriggerManual   CaptureTrigger = 0
+	TriggerIgnition CaptureTrigger = 1
+	TriggerLockout  CaptureTrigger = 2
+	TriggerError    CaptureTrigger = 3
+)
+
+var captureTriggerNames = [...]string{"Manual", "Ignition", "Lockout", "Error"}
+
+func (t CaptureTrigger) String() string {
+	if t < 0 || int(t) >= len(captureTriggerNames) {
+		return fmt.Sprintf("Unknown (%d)", int(t))
+	}
+	return captureTriggerNames[t]
+}
+
+// BoilerCaptureData represents the data for a single sample in the boiler's capture buffer.
+type BoilerCaptureData struct {
+	//"rbid": 0
+	//"object_no": 26
+	//object_index: sample
+	// Samples is the number of samples in the capture buffer.
+	Samples int `json:"Samples"`
+	// Interval is the time between samples, in milliseconds.
+	Interval int `json:"Interval"`
+	// Date and Time are when the capture was triggered.
+	Date       string         `json:"Date"`
+	Time       string         `json:"Time"`
+	Trigger    CaptureTrigger `json:"Trigger"`
+	OpStatus   OpStatus       `json:"OpStatus"`
+	FanRPM     int            `json:"FanRPM"`
+	HeatOut    int            `json:"HeatOut"`
+	SupplyTemp Temperature    `json:"SupplyT"`
+	ReturnTemp Temperature    `json:"ReturnT"`
+	StackTemp  Temperature    `json:"StackT"`
+	FlameSense int            `json:"FlameSense"`
+}
+
+// GetBoilerCaptureData returns the BoilerCaptureData for the specified sample.
+func (b Boiler) GetBoilerCaptureData(sample int) (BoilerCaptureData, error) {
+	return b.GetBoilerCaptureDataContext(context.Background(), sample)
+}
+
+// GetBoilerCaptureDataContext returns the BoilerCaptureData for the specified sample using the provided context.
+func (b Boiler) GetBoilerCaptureDataContext(ctx context.Context, sample int) (BoilerCaptureData, error) {
+	reqObj := requestObject{ObjectNum: 100, ObjectRequest: ReqBoilerCaptureData, BoilerNum: b.BoilerNum, ObjectIndex: sample}
+	var respObj = BoilerCaptureData{}
+	return respObj, b.getData(ctx, reqObj, &respObj)
+}
+
+// Capture is the time series held in the boiler's capture buffer.
+type Capture struct {
+	// Time is when the capture was triggered, according to the boiler clock. It is zero if the boiler's date could not be parsed.
+	Time     time.Time
+	Trigger  CaptureTrigger
+	Interval time.Duration
+	Samples  []CaptureSample
+}
+
+// CaptureSample is a single sample of a Capture.
+type CaptureSample struct {
+	// Offset is the time of the sample since the start of the capture.
+	Offset     time.Duration
+	OpStatus   OpStatus
+	FanRPM     int
+	HeatOut    int
+	SupplyTemp Temperature
+	ReturnTemp Temperature
+	StackTemp  Temperature
+	FlameSense int
+}
+
+// Capture reads every sample in the boiler's capture buffer.
+func (b Boiler) Capture() (Capture, error) {
+	return b.CaptureContext(context.Background())
+}
+
+// CaptureContext reads every sample in the boiler's capture buffer using the provided context. The samples read
+// before an error occurs are returned along with the error. An error is returned if the boiler takes a new
+// capture while the buffer is being read.
+func (b Boiler) CaptureContext(ctx context.Context) (Capture, error) {
+	var c Capture
+	first, err := b.GetBoilerCaptureDataContext(ctx, 0)
+	if err != nil {
+		return c, err
+	}
+	c.Time, _ = ParseBoilerTime(first.Date, first.Time, b.location())
+	c.Trigger = first.Trigger
+	c.Interval = time.Duration(first.Interval) * time.Millisecond
+	c.Samples = make([]CaptureSample, 0, first.Samples)
+
+	for i := 0; i < first.Samples; i++ {
+		data := first
+		if i > 0 {
+			if data, err = b.GetBoilerCaptureDataContext(ctx, i); err != nil {
+				return c, err
+			}
+			if data.Date != first.Date || data.Time != first.Time {
+				return c, fmt.Errorf("ibc: a new capture was taken while sample %d was read", i)
+			}
+		}
+		c.Samples = append(c.Samples, CaptureSample{
+			Offset:     time.Duration(i) * c.Interval,
+			OpStatus:   data.OpStatus,
+			FanRPM:     data.FanRPM,
+			HeatOut:    data.HeatOut,
+			SupplyTemp: data.SupplyTemp,
+			ReturnTemp: data.ReturnTemp,
+			StackTemp:  data.StackTemp,
+			FlameSense: data.FlameSense,
+		})
+	}
+	return c, nil
+}
@@ -0,0 +1,67 @@
+package ibc
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestGetBoilerRunProfileData(t *testing.T) {
+	b, done := newTestBoiler(func(req requestObject) (int, string) {
+		return http.StatusOK, `{"PrePurge":10,"IgnTime":4,"PostPurge":30,"PurgeRPM":5000,"IgnRPM":3000,"MinRPM":1500,"MaxRPM":6200,"Trials":3}`
+	})
+	defer done()
+
+	rpd, err := b.GetBoilerRunProfileData()
+	want := BoilerRunProfileData{PrePurge: 10, Ignition: 4, PostPurge: 30, PurgeRPM: 5000, IgnitionRPM: 3000, MinRPM: 1500, MaxRPM: 6200, Trials: 3}
+	if err != nil || rpd != want {
+		t.Errorf("GetBoilerRunProfileData is incorrect, got: %+v %v, want: %+v.", rpd, err, want)
+	}
+}
+
+// captureObjects returns a 3 sample lockout capture of an ignition sequence taken at clock.
+func captureObjects(clock string) []fakeObject {
+	objects := make([]fakeObject, 3)
+	for i := range objects {
+		objects[i] = fakeObject{ReqBoilerCaptureData, 0, i, fmt.Sprintf(`{"rbid":0,"object_no":26,"Samples":3,"Interval":500,`+
+			`"Date":"02/10/19","Time":"%s","Trigger":2,"OpStatus":%d,"FanRPM":%d,"HeatOut":0,"SupplyT":200,"ReturnT":180,"StackT":100,`+
+			`"FlameSense":0}`, clock, OpPrePurge+OpStatus(i), 5000-1000*i)}
+	}
+	return objects
+}
+
+func TestCapture(t *testing.T) {
+	b, _, done := newFakeBoiler(captureObjects("06:15:00")...)
+	defer done()
+	b.Location = time.UTC
+
+	c, err := b.Capture()
+	if err != nil {
+		t.Fatalf("Capture returned error: %v", err)
+	}
+	if !c.Time.Equal(time.Date(2019, time.February, 10, 6, 15, 0, 0, time.UTC)) || c.Trigger != TriggerLockout || c.Interval != 500*time.Millisecond {
+		t.Errorf("Capture is incorrect, got: %v %v %v.", c.Time, c.Trigger, c.Interval)
+	}
+	if len(c.Samples) != 3 {
+		t.Fatalf("Capture samples length is incorrect, got: %d, want: %d.", len(c.Samples), 3)
+	}
+	want := CaptureSample{Offset: time.Second, OpStatus: OpModulating, FanRPM: 3000, SupplyTemp: 200, ReturnTemp: 180, StackTemp: 100}
+	if c.Samples[2] != want {
+		t.Errorf("Capture sample is incorrect, got: %+v, want: %+v.", c.Samples[2], want)
+	}
+}
+
+func TestCaptureRetaken(t *testing.T) {
+	b, fb, done := newFakeBoiler(captureObjects("06:15:00")...)
+	defer done()
+	b.Location = time.UTC
+	fb.onRead = func(req requestObject) {
+		fb.setAll(captureObjects("06:20:00")...)
+	}
+
+	c, err := b.Capture()
+	if err == nil || len(c.Samples) != 1 {
+		t.Errorf("Capture while a new capture is taken is incorrect, got: %d samples, %v.", len(c.Samples), err)
+	}
+}
@@ -0,0 +1,140 @@
+package ibc
+
+import "context"
+
+// BoilerRole describes the part a boiler plays on a cascade network.
+type BoilerRole int
+
+// Boiler Role Constants
+const (
+	RoleStandalone BoilerRole = 0
+	RoleMaster     BoilerRole = 1
+	RoleSlave      BoilerRole = 2
+)
+
+var boilerRoleNames = [...]string{"Standalone", "Master", "Slave"}
+
+func (r BoilerRole) String() string {
+	if r < 0 || int(r) >= len(boilerRoleNames) {
+		return "Unknown"
+	}
+	return boilerRoleNames[r]
+}
+
+// MasterBoilerData represents the data returned by the ReqMasterBoilerData request.
+// It describes the cascade as a whole from the point of view of the master boiler.
+type MasterBoilerData struct {
+	//"rbid": 0
+	//"object_no": 2
+	MasterID      int `json:"MasterID"`
+	LeadBoiler    int `json:"LeadBoiler"`
+	NetBoilers    int `json:"NetBoilers"`
+	OnlineBoilers int `json:"OnlineBoilers"`
+	FiringBoilers int `json:"FiringBoilers"`
+	// HeatOut is the combined firing rate of the cascade as a percentage of its total capacity.
+	HeatOut    int         `json:"HeatOut"`
+	MBH        int         `json:"MBH"`
+	SupplyTemp Temperature `json:"SupplyT"`
+	ReturnTemp Temperature `json:"ReturnT"`
+	TargetTemp Temperature `json:"TargetT"`
+}
+
+// NetworkBoilerData represents the data returned by the ReqNetworkBoilerData request.
+type NetworkBoilerData struct {
+	//"rbid": 0
+	//"object_no": 38
+	NumBoilers int             `json:"NumBoilers"`
+	Boilers    []NetworkBoiler `json:"Boilers"`
+}
+
+// NetworkBoiler represents a single boiler in the NetworkBoilerData response.
+type NetworkBoiler struct {
+	// BoilerNum is the number used to make requests for this boiler. See Boiler.Unit.
+	BoilerNum int        `json:"BoilerNo"`
+	BoilerID  int        `json:"BoilerID"`
+	Role      BoilerRole `json:"Role"`
+	Online    bool       `json:"Online"`
+	Status    Status     `json:"Status"`
+	// FiringRate is the current firing rate of this boiler as a percentage of its capacity.
+	FiringRate int    `json:"FiringRate"`
+	MBH        int    `json:"MBH"`
+	Model      string `json:"Model"`
+}
+
+// Master returns the master boiler on the network, if there is one.
+func (nbd NetworkBoilerData) Master() (NetworkBoiler, bool) {
+	for _, nb := range nbd.Boilers {
+		if nb.Role == RoleMaster {
+			return nb, true
+		}
+	}
+	return NetworkBoiler{}, false
+}
+
+// Online returns the boilers on the network that are currently online.
+func (nbd NetworkBoilerData) Online() []NetworkBoiler {
+	online := make([]NetworkBoiler, 0, len(nbd.Boilers))
+	for _, nb := range nbd.Boilers {
+		if nb.Online {
+			online = append(online, nb)
+		}
+	}
+	return online
+}
+
+// GetMasterBoilerData returns the MasterBoilerData response for the current boiler.
+func (b Boiler) GetMasterBoilerData() (MasterBoilerData, error) {
+	return b.GetMasterBoilerDataContext(context.Background())
+}
+
+// GetMasterBoilerDataContext returns the MasterBoilerData response for the current boiler using the provided context.
+func (b Boiler) GetMasterBoilerDataContext(ctx context.Context) (MasterBoilerData, error) {
+	reqObj := requestObject{ObjectNum: 100, ObjectRequest: ReqMasterBoilerData, BoilerNum: b.BoilerNum}
+	var respObj = MasterBoilerData{}
+	return respObj, b.getData(ctx, reqObj, &respObj)
+}
+
+// GetNetworkBoilerData returns the NetworkBoilerData response for the current boiler.
+func (b Boiler) GetNetworkBoilerData() (NetworkBoilerData, error) {
+	return b.GetNetworkBoilerDataContext(context.Background())
+}
+
+// GetNetworkBoilerDataContext returns the NetworkBoilerData response for the current boiler using the provided context.
+func (b Boiler) GetNetworkBoilerDataContext(ctx context.Context) (NetworkBoilerData, error) {
+	reqObj := requestObject{ObjectNum: 100, ObjectRequest: ReqNetworkBoilerData, BoilerNum: b.BoilerNum}
+	var respObj = NetworkBoilerData{}
+	return respObj, b.getData(ctx, reqObj, &respObj)
+}
+
+// Unit returns a Boiler that makes requests for the specified boiler number on the cascade network
+// reachable through b. All other settings are copied from b.
+func (b Boiler) Unit(boilerNum int) Boiler {
+	u := b
+	u.BoilerNum = boilerNum
+	return u
+}
+
+// Units returns a Boiler for each boiler on the cascade network reachable through b.
+// A boiler that is not part of a cascade returns a single Boiler for itself.
+func (b Boiler) Units() ([]Boiler, error) {
+	return b.UnitsContext(context.Background())
+}
+
+// UnitsContext returns a Boiler for each boiler on the cascade network reachable through b using the provided context.
+// A boiler that is not part of a cascade returns a single Boiler for itself.
+func (b Boiler) UnitsContext(ctx context.Context) ([]Boiler, error) {
+	nbd, err := b.GetNetworkBoilerDataContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(nbd.Boilers) == 0 {
+		return []Boiler{b.Unit(0)}, nil
+	}
+
+	units := make([]Boiler, len(nbd.Boilers))
+	for i, nb := range nbd.Boilers {
+		units[i] = b.Unit(nb.BoilerNum)
+	}
+	return units, nil
+}
@@ -0,0 +1,89 @@
+package ibc
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestUnitsUsesBoilerNum(t *testing.T) {
+	var gotBoilerNums []int
+	b, done := newTestBoiler(func(req requestObject) (int, string) {
+		if req.ObjectRequest == ReqNetworkBoilerData {
+			return http.StatusOK, `{"object_no":38,"Boilers":[{"BoilerNo":0},{"BoilerNo":1},{"BoilerNo":2}]}`
+		}
+		gotBoilerNums = append(gotBoilerNums, req.BoilerNum)
+		return http.StatusOK, `{"status":0}`
+	})
+	defer done()
+
+	units, err := b.Units()
+	if err != nil {
+		t.Fatalf("Units returned error: %v", err)
+	}
+	if len(units) != 3 {
+		t.Fatalf("Units is incorrect, got: %d units, want: 3.", len(units))
+	}
+	for _, u := range units {
+		if _, err := u.GetBoilerData(); err != nil {
+			t.Fatalf("GetBoilerData returned error: %v", err)
+		}
+	}
+	for i, n := range gotBoilerNums {
+		if n != i {
+			t.Errorf("boiler_no is incorrect, got: %d, want: %d.", n, i)
+		}
+	}
+}
+
+func TestUnitsStandalone(t *testing.T) {
+	b, done := newTestBoiler(func(req requestObject) (int, string) {
+		return http.StatusOK, `{"object_no":38,"Boilers":[]}`
+	})
+	defer done()
+
+	units, err := b.Units()
+	if err != nil {
+		t.Fatalf("Units returned error: %v", err)
+	}
+	if len(units) != 1 || units[0].BoilerNum != 0 {
+		t.Errorf("Units is incorrect, got: %+v, want: a single boiler 0.", units)
+	}
+}
+
+func TestGetNetworkBoilerData(t *testing.T) {
+	b, done := newFixtureBoiler(t, map[int]string{ReqNetworkBoilerData: "network_boiler_data.json"})
+	defer done()
+
+	nbd, err := b.GetNetworkBoilerData()
+	if err != nil {
+		t.Fatalf("GetNetworkBoilerData returned error: %v", err)
+	}
+	if nbd.NumBoilers != 3 || len(nbd.Boilers) != 3 {
+		t.Fatalf("NumBoilers is incorrect, got: %d (%d listed), want: 3.", nbd.NumBoilers, len(nbd.Boilers))
+	}
+	master, ok := nbd.Master()
+	if !ok || master.BoilerID != 1 || master.FiringRate != 62 {
+		t.Errorf("Master is incorrect, got: %+v.", master)
+	}
+	if nbd.Boilers[1].Role != RoleSlave || nbd.Boilers[1].Role.String() != "Slave" {
+		t.Errorf("Role is incorrect, got: %v, want: Slave.", nbd.Boilers[1].Role)
+	}
+	if online := nbd.Online(); len(online) != 2 {
+		t.Errorf("Online is incorrect, got: %d boilers, want: 2.", len(online))
+	}
+}
+
+func TestGetMasterBoilerData(t *testing.T) {
+	b, done := newFixtureBoiler(t, map[int]string{ReqMasterBoilerData: "master_boiler_data.json"})
+	defer done()
+
+	mbd, err := b.GetMasterBoilerData()
+	if err != nil {
+		t.Fatalf("GetMasterBoilerData returned error: %v", err)
+	}
+	want := MasterBoilerData{MasterID: 1, LeadBoiler: 2, NetBoilers: 3, OnlineBoilers: 2, FiringBoilers: 2,
+		HeatOut: 32, MBH: 111, SupplyTemp: 284, ReturnTemp: 236, TargetTemp: 288}
+	if mbd != want {
+		t.Errorf("GetMasterBoilerData is incorrect, got: %+v, want: %+v.", mbd, want)
+	}
+}
@@ -0,0 +1,193 @@
+package ibc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrClockNotSet is returned when the boiler clock does not hold a valid date and time, usually after a power outage.
+var ErrClockNotSet = errors.New("ibc: boiler clock is not set")
+
+// ClockData represents the data returned by the ReqClockData request.
+type ClockData struct {
+	//"rbid": 0
+	//"object_no": 24
+	// Year is the last two digits of the year. The boiler clock holds the years 2000 through 2099.
+	Year      int `json:"Year"`
+	Month     int `json:"Month"`
+	Day       int `json:"Day"`
+	Hour      int `json:"Hour"`
+	Minute    int `json:"Minute"`
+	Second    int `json:"Second"`
+	DayOfWeek int `json:"DOW"`
+}
+
+// Time returns the boiler clock as a time.Time in the specified location. ErrClockNotSet is returned if the clock is not set
+// or does not hold a valid date.
+func (cd ClockData) Time(loc *time.Location) (time.Time, error) {
+	if cd.Year < 0 || cd.Year > 99 || cd.Month < 1 || cd.Month > 12 || cd.Day < 1 || cd.Day > 31 ||
+		cd.Hour < 0 || cd.Hour > 23 || cd.Minute < 0 || cd.Minute > 59 || cd.Second < 0 || cd.Second > 59 {
+		return time.Time{}, fmt.Errorf("%w: %+v", ErrClockNotSet, cd)
+	}
+	t := time.Date(2000+cd.Year, time.Month(cd.Month), cd.Day, cd.Hour, cd.Minute, cd.Second, 0, loc)
+	// time.Date normalizes dates such as February 31 into the following month.
+	if t.Month() != time.Month(cd.Month) || t.Day() != cd.Day {
+		return time.Time{}, fmt.Errorf("%w: %+v", ErrClockNotSet, cd)
+	}
+	return t, nil
+}
+
+// GetClockData returns the ClockData response for the current boiler.
+func (b Boiler) GetClockData() (ClockData, error) {
+	return b.GetClockDataContext(context.Background())
+}
+
+// GetClockDataContext returns the ClockData response for the current boiler using the provided context.
+func (b Boiler) GetClockDataContext(ctx context.Context) (ClockData, error) {
+	reqObj := requestObject{ObjectNum: 100, ObjectRequest: ReqClockData, BoilerNum: b.BoilerNum}
+	var respObj = ClockData{}
+	return respObj, b.getData(ctx, reqObj, &respObj)
+}
+
+// GetClock returns the time on the boiler clock, in the Boiler's Location.
+func (b Boiler) GetClock() (time.Time, error) {
+	return b.GetClockContext(context.Background())
+}
+
+// GetClockContext returns the time on the boiler clock, in the Boiler's Location, using the provided context.
+func (b Boiler) GetClockContext(ctx context.Context) (time.Time, error) {
+	cd, err := b.GetClockDataContext(ctx)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return cd.Time(b.location())
+}
+
+// ClockDrift compares the boiler clock to the host clock.
+type ClockDrift struct {
+	Boiler time.Time
+	Host   time.Time
+	// Drift is how far the boiler clock is ahead of the host clock. It is negative when the boiler is behind.
+	Drift time.Duration
+}
+
+// Exceeds returns true if the boiler clock is more than threshold ahead of or behind the host clock.
+func (d ClockDrift) Exceeds(threshold time.Duration) bool {
+	return abs(d.Drift) > threshold
+}
+
+// DSTMismatch returns true if the drift is an hour, within tolerance, and the boiler's location observes daylight
+// saving time. This usually means the boiler clock was not moved forward or back at the last change.
+func (d ClockDrift) DSTMismatch(tolerance time.Duration) bool {
+	if !observesDST(d.Boiler.Location(), d.Host.Year()) {
+		return false
+	}
+	return abs(abs(d.Drift)-time.Hour) <= tolerance
+}
+
+func observesDST(loc *time.Location, year int) bool {
+	_, jan := time.Date(year, time.January, 1, 0, 0, 0, 0, loc).Zone()
+	_, jul := time.Date(year, time.July, 1, 0, 0, 0, 0, loc).Zone()
+	return jan != jul
+}
+
+// CheckClock compares the boiler clock to the host clock.
+func (b Boiler) CheckClock() (ClockDrift, error) {
+	return b.CheckClockContext(context.Background())
+}
+
+// CheckClockContext compares the boiler clock to the host clock using the provided context.
+// The host time is taken halfway through the request to allow for network latency. When the boiler's
+// wall clock time occurs twice because daylight saving time ended, the reading closest to the host clock is used.
+func (b Boiler) CheckClockContext(ctx context.Context) (ClockDrift, error) {
+	start := time.Now()
+	cd, err := b.GetClockDataContext(ctx)
+	if err != nil {
+		return ClockDrift{}, err
+	}
+	host := start.Add(time.Since(start) / 2)
+
+	boiler, err := cd.Time(b.location())
+	if err != nil {
+		return ClockDrift{}, err
+	}
+	boiler = closestWallTime(boiler, host)
+	return ClockDrift{Boiler: boiler, Host: host.In(boiler.Location()), Drift: boiler.Sub(host)}, nil
+}
+
+// closestWallTime returns the instant with the same wall clock time as t that is closest to ref. The two differ
+// only during the hour that repeats when daylight saving time ends.
+func closestWallTime(t time.Time, ref time.Time) time.Time {
+	best := t
+	for _, d := range [...]time.Duration{-time.Hour, time.Hour} {
+		alt := t.Add(d)
+		if !sameWallTime(alt, t) {
+			continue
+		}
+		if abs(alt.Sub(ref)) < abs(best.Sub(ref)) {
+			best = alt
+		}
+	}
+	return best
+}
+
+func sameWallTime(a time.Time, b time.Time) bool {
+	ay, am, ad := a.Date()
+	by, bm, bd := b.Date()
+	return ay == by && am == bm && ad == bd && a.Hour() == b.Hour() && a.Minute() == b.Minute() && a.Second() == b.Second()
+}
+
+func abs(d time.Duration) time.Duration {
+	if d < 0 {
+		return -d
+	}
+	return d
+}
+
+// clockVerifyTolerance is how far the clock read back after SetClock may be from the time written.
+const clockVerifyTolerance = 5 * time.Second
+
+// SetClock sets the boiler clock to t, converted to the Boiler's Location. The clock is read back after
+// the write, and a *VerifyError is returned if it does not match. A *RangeError is returned if t is not in the
+// years 2000 through 2099, which the boiler clock can not hold.
+func (b Boiler) SetClock(t time.Time) (WriteResult, error) {
+	return b.SetClockContext(context.Background(), t)
+}
+
+// SetClockContext sets the boiler clock to t, converted to the Boiler's Location, using the provided context.
+// The clock is read back after the write, and a *VerifyError is returned if it does not match.
+func (b Boiler) SetClockContext(ctx context.Context, t time.Time) (WriteResult, error) {
+	t = t.In(b.location()).Truncate(time.Second)
+	if t.Year() < 2000 || t.Year() > 2099 {
+		return WriteResult{}, &RangeError{Field: "Year", Value: t.Year(), Min: 2000, Max: 2099}
+	}
+	cd := ClockData{
+		Year:      t.Year() - 2000,
+		Month:     int(t.Month()),
+		Day:       t.Day(),
+		Hour:      t.Hour(),
+		Minute:    t.Minute(),
+		Second:    t.Second(),
+		DayOfWeek: int(t.Weekday()),
+	}
+	written := time.Now()
+	res, err := b.writeData(ctx, ReqClockData, cd)
+	if err != nil || !res.Sent {
+		return res, err
+	}
+
+	got, err := b.GetClockContext(ctx)
+	if errors.Is(err, ErrClockNotSet) {
+		return res, &VerifyError{Request: ReqClockData, Written: t, Read: "clock not set"}
+	} else if err != nil {
+		return res, err
+	}
+	want := t.Add(time.Since(written))
+	if abs(got.Sub(want)) > clockVerifyTolerance {
+		return res, &VerifyError{Request: ReqClockData, Written: t, Read: got}
+	}
+	res.Verified = true
+	return res, nil
+}
@@ -0,0 +1,123 @@
+package ibc
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestClockDataTime(t *testing.T) {
+	cd := ClockData{Year: 18, Month: 12, Day: 3, Hour: 14, Minute: 22, Second: 5}
+	got, err := cd.Time(time.UTC)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := time.Date(2018, time.December, 3, 14, 22, 5, 0, time.UTC); !got.Equal(want) {
+		t.Errorf("ClockData.Time is incorrect, got: %v, want: %v.", got, want)
+	}
+
+	invalid := []ClockData{
+		{},
+		{Year: 18, Month: 2, Day: 31, Hour: 14},
+		{Year: 19, Month: 2, Day: 29, Hour: 14},
+		{Year: 2018, Month: 12, Day: 3, Hour: 14},
+	}
+	for _, cd := range invalid {
+		if _, err := cd.Time(time.UTC); !errors.Is(err, ErrClockNotSet) {
+			t.Errorf("ClockData.Time(%+v) error is incorrect, got: %v, want: %v.", cd, err, ErrClockNotSet)
+		}
+	}
+}
+
+func TestCheckClock(t *testing.T) {
+	boilerTime := time.Now().UTC().Add(-10 * time.Minute)
+	b, done := newTestBoiler(func(req requestObject) (int, string) {
+		return http.StatusOK, fmt.Sprintf(`{"Year":%d,"Month":%d,"Day":%d,"Hour":%d,"Minute":%d,"Second":%d}`,
+			boilerTime.Year()%100, boilerTime.Month(), boilerTime.Day(), boilerTime.Hour(), boilerTime.Minute(), boilerTime.Second())
+	})
+	defer done()
+	b.Location = time.UTC
+
+	drift, err := b.CheckClock()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if drift.Drift > -9*time.Minute || drift.Drift < -11*time.Minute {
+		t.Errorf("Drift is incorrect, got: %v, want: about -10m.", drift.Drift)
+	}
+	if !drift.Exceeds(5*time.Minute) || drift.Exceeds(15*time.Minute) {
+		t.Errorf("Exceeds is incorrect for a drift of %v.", drift.Drift)
+	}
+	if drift.DSTMismatch(time.Minute) {
+		t.Error("DSTMismatch is true for a location without daylight saving time.")
+	}
+}
+
+func TestClockDriftAroundDST(t *testing.T) {
+	loc, err := time.LoadLocation("America/Denver")
+	if err != nil {
+		t.Skip("time zone data not available:", err)
+	}
+
+	// 1:30am occurs twice on November 4, 2018. A boiler reading 1:30 while the host is in the second
+	// 1:30 (MST) has no drift.
+	host := time.Date(2018, time.November, 4, 8, 30, 0, 0, time.UTC).In(loc)
+	boiler := closestWallTime(time.Date(2018, time.November, 4, 1, 30, 0, 0, loc), host)
+	if !boiler.Equal(host) {
+		t.Errorf("closestWallTime is incorrect, got: %v, want: %v.", boiler, host)
+	}
+
+	// A boiler that was not moved back an hour is an hour ahead.
+	host = time.Date(2018, time.November, 5, 12, 0, 0, 0, loc)
+	drift := ClockDrift{Boiler: host.Add(time.Hour), Host: host, Drift: time.Hour}
+	if !drift.DSTMismatch(2 * time.Minute) {
+		t.Error("DSTMismatch is false for a boiler an hour ahead after the change.")
+	}
+}
+
+// unsetClock is the clock of a boiler that has not been set.
+var unsetClock = fakeObject{ReqClockData, 0, 0, `{"rbid":0,"object_no":24,"Year":0,"Month":0,"Day":0}`}
+
+func TestSetClock(t *testing.T) {
+	b, _, done := newFakeBoiler(unsetClock)
+	defer done()
+	b.Location = time.UTC
+	b.AllowWrites = true
+
+	want := time.Date(2018, time.December, 3, 14, 22, 5, 0, time.UTC)
+	res, err := b.SetClock(want)
+	if err != nil {
+		t.Fatalf("SetClock returned error: %v", err)
+	}
+	if !res.Sent || !res.Verified {
+		t.Errorf("SetClock result is incorrect, got: %+v, want: sent and verified.", res)
+	}
+	got, err := b.GetClock()
+	if err != nil || !got.Equal(want) {
+		t.Errorf("GetClock after SetClock is incorrect, got: %v %v, want: %v.", got, err, want)
+	}
+	if cd, _ := b.GetClockData(); cd.Year != 18 {
+		t.Errorf("Year written by SetClock is incorrect, got: %d, want: 18.", cd.Year)
+	}
+
+	var rangeErr *RangeError
+	if _, err := b.SetClock(time.Date(2100, time.January, 1, 0, 0, 0, 0, time.UTC)); !errors.As(err, &rangeErr) {
+		t.Errorf("SetClock error is incorrect, got: %v, want: *RangeError.", err)
+	}
+}
+
+func TestSetClockNotApplied(t *testing.T) {
+	b, fb, done := newFakeBoiler(unsetClock)
+	defer done()
+	fb.ignoreWrites = true
+	b.Location = time.UTC
+	b.AllowWrites = true
+
+	_, err := b.SetClock(time.Now())
+	var verifyErr *VerifyError
+	if !errors.As(err, &verifyErr) {
+		t.Errorf("SetClock error is incorrect, got: %v, want: *VerifyError.", err)
+	}
+}
@@ -0,0 +1,388 @@
+package ibc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ConfigVersion is the version of the Config document written by ExportConfig. ImportConfig refuses other versions.
+const ConfigVersion = 1
+
+// ErrImportDeclined is returned by ImportConfig when the confirm callback rejects the changes.
+var ErrImportDeclined = errors.New("ibc: configuration import declined")
+
+// Config is a backup of every settings object of a boiler. The objects are kept as the raw maps returned by the
+// boiler, so fields this package does not decode are preserved. The rbid and object_no keys the boiler tags each
+// object with are left out, so a Config can be restored to a boiler with a different BoilerNum.
+type Config struct {
+	Version         int       `json:"version"`
+	Exported        time.Time `json:"exported"`
+	BoilerNum       int       `json:"boilerNum"`
+	Model           string    `json:"model"`
+	ModelNum        int       `json:"modelNum"`
+	FirmwareVersion string    `json:"firmwareVersion"`
+	// Units are the units the boiler is configured to display, used to format changes.
+	Units   Units          `json:"units"`
+	Objects []ConfigObject `json:"objects"`
+}
+
+// ConfigObject is a single settings object in a Config.
+type ConfigObject struct {
+	Request int    `json:"request"`
+	Name    string `json:"name"`
+	// Load is the 1 based load number for per-load objects, otherwise 0.
+	Load int `json:"load,omitempty"`
+	// Index is the object index, the day of the week for programmable setback.
+	Index int                    `json:"index,omitempty"`
+	Data  map[string]interface{} `json:"data"`
+}
+
+// Key identifies the object within a Config, ex "Load Settings (load 2)".
+func (o ConfigObject) Key() string {
+	key := o.Name
+	if o.Load > 0 {
+		key += fmt.Sprintf(" (load %d", o.Load)
+		if o.Request == ReqProgSetbackData {
+			key += ", " + time.Weekday(o.Index).String()
+		}
+		key += ")"
+	}
+	return key
+}
+
+// configRequests lists the settings objects included in a Config, in the order they are read and written.
+// Objects that are not restored are exported for reference only.
+var configRequests = []struct {
+	request int
+	name    string
+	perLoad bool
+	indexes int
+	restore bool
+}{
+	{ReqBoilerStandardData, "Standard Settings", false, 1, true},
+	{ReqBoilerAdvSetttingsData, "Advanced Settings", false, 1, true},
+	{ReqBoilerLoadSettingsData, "Load Settings", true, 1, true},
+	{ReqBoilerSetbackData, "Setback", true, 1, true},
+	{ReqProgSetbackData, "Programmable Setback", true, 7, true},
+	{ReqBoilerMultiSettingData, "Multi Boiler Settings", false, 1, true},
+	{ReqBoilerCleaningSettingData, "Cleaning Settings", false, 1, true},
+	// Factory settings are calibrated for the boiler they were read from.
+	{ReqBoilerFactorySettingsData, "Factory Settings", false, 1, false},
+	{ReqAdvancedOptionsData, "Advanced Options", false, 1, true},
+}
+
+// restored returns true if ImportConfig restores objects for the request.
+func restored(request int) bool {
+	for _, cr := range configRequests {
+		if cr.request == request {
+			return cr.restore
+		}
+	}
+	return false
+}
+
+// Object returns the object in the Config for the specified request, load and index.
+func (c Config) Object(request int, load int, index int) (ConfigObject, bool) {
+	for _, o := range c.Objects {
+		if o.Request == request && o.Load == load && o.Index == index {
+			return o, true
+		}
+	}
+	return ConfigObject{}, false
+}
+
+// ExportConfig reads every settings object from the boiler. Objects the firmware does not support are left out.
+func (b Boiler) ExportConfig() (Config, error) {
+	return b.ExportConfigContext(context.Background())
+}
+
+// ExportConfigContext reads every settings object from the boiler using the provided context.
+func (b Boiler) ExportConfigContext(ctx context.Context) (Config, error) {
+	bd, err := b.GetBoilerDataContext(ctx)
+	if err != nil {
+		return Config{}, err
+	}
+	c := Config{
+		Version:         ConfigVersion,
+		Exported:        time.Now(),
+		BoilerNum:       b.BoilerNum,
+		Model:           bd.Model,
+		ModelNum:        bd.ModelNum,
+		FirmwareVersion: bd.FirmwareVersion,
+		Units:           bd.Units(),
+	}
+
+	for _, cr := range configRequests {
+		loads := []int{0}
+		if cr.perLoad {
+			loads = []int{1, 2, 3, 4}
+		}
+		for _, load := range loads {
+			for index := 0; index < cr.indexes; index++ {
+				o := ConfigObject{Request: cr.request, Name: cr.name, Load: load, Index: index}
+				o.Data, err = b.getObject(ctx, cr.request, load, index)
+				if errors.Is(err, ErrUnsupported) {
+					continue
+				}
+				if err != nil {
+					return c, fmt.Errorf("ibc: unable to export %s: %w", o.Key(), err)
+				}
+				c.Objects = append(c.Objects, o)
+			}
+		}
+	}
+	return c, nil
+}
+
+// getObject reads a settings object as a raw map, without the envelope keys.
+func (b Boiler) getObject(ctx context.Context, request int, load int, index int) (map[string]interface{}, error) {
+	reqObj := requestObject{ObjectNum: 100, ObjectRequest: request, BoilerNum: b.BoilerNum, LoadNum: load, ObjectIndex: index}
+	var respObj map[string]interface{}
+	if err := b.getData(ctx, reqObj, &respObj); err != nil {
+		return respObj, err
+	}
+	for k := range envelopeKeys {
+		delete(respObj, k)
+	}
+	return respObj, nil
+}
+
+// ConfigChange is a field that differs between two configurations.
+type ConfigChange struct {
+	Object ConfigObject
+	Field  string
+	// From and To are nil if the field is missing from that configuration.
+	From interface{}
+	To   interface{}
+}
+
+func (c ConfigChange) String() string {
+	return fmt.Sprintf("%s %s: %v -> %v", c.Object.Key(), c.Field, c.From, c.To)
+}
+
+// Format returns the change with the values of known fields converted to the specified units,
+// ex "Load Settings (load 2) SetPoint: 180°F -> 175°F".
+func (c ConfigChange) Format(u Units) string {
+	return fmt.Sprintf("%s %s: %s -> %s", c.Object.Key(), c.Field, formatConfigValue(c.Object.Request, c.Field, c.From, u),
+		formatConfigValue(c.Object.Request, c.Field, c.To, u))
+}
+
+// configTypes maps the settings objects this package decodes to their types, so changes to them can be formatted.
+var configTypes = map[int]reflect.Type{
+	ReqBoilerStandardData:        reflect.TypeOf(BoilerStandardData{}),
+	ReqBoilerAdvSetttingsData:    reflect.TypeOf(BoilerAdvSettingsData{}),
+	ReqBoilerLoadSettingsData:    reflect.TypeOf(LoadSettingsData{}),
+	ReqBoilerSetbackData:         reflect.TypeOf(SetbackData{}),
+	ReqProgSetbackData:           reflect.TypeOf(ProgSetbackData{}),
+	ReqBoilerMultiSettingData:    reflect.TypeOf(BoilerMultiSettingData{}),
+	ReqBoilerCleaningSettingData: reflect.TypeOf(BoilerCleaningSettingData{}),
+}
+
+type unitFormatter interface {
+	Format(u Units) string
+}
+
+// formatConfigValue formats a raw value from a settings object as the type of the field it was read from, if known.
+func formatConfigValue(request int, field string, value interface{}, u Units) string {
+	if value == nil {
+		return "(none)"
+	}
+	if t, ok := configTypes[request]; ok {
+		if f, ok := jsonField(t, field); ok {
+			v := reflect.New(f.Type)
+			if jsonBytes, err := json.Marshal(value); err == nil && json.Unmarshal(jsonBytes, v.Interface()) == nil {
+				return formatValue(v.Elem(), u)
+			}
+		}
+	}
+	return fmt.Sprint(value)
+}
+
+func formatValue(v reflect.Value, u Units) string {
+	switch x := v.Interface().(type) {
+	case unitFormatter:
+		return x.Format(u)
+	case fmt.Stringer:
+		return x.String()
+	}
+	if v.Kind() == reflect.Slice {
+		parts := make([]string, v.Len())
+		for i := range parts {
+			parts[i] = formatValue(v.Index(i), u)
+		}
+		return "[" + strings.Join(parts, ", ") + "]"
+	}
+	return fmt.Sprint(v.Interface())
+}
+
+// jsonField returns the field of struct type t that is encoded with the JSON key name.
+func jsonField(t reflect.Type, name string) (reflect.StructField, bool) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := strings.Split(f.Tag.Get("json"), ",")[0]
+		if tag == name || (tag == "" && f.Name == name) {
+			return f, true
+		}
+	}
+	return reflect.StructField{}, false
+}
+
+// ConfigDiff is the list of changes between two configurations.
+type ConfigDiff []ConfigChange
+
+func (d ConfigDiff) String() string {
+	lines := make([]string, 0, len(d))
+	for _, c := range d {
+		lines = append(lines, c.String())
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Format returns the changes one per line, with the values of known fields converted to the specified units.
+func (d ConfigDiff) Format(u Units) string {
+	lines := make([]string, 0, len(d))
+	for _, c := range d {
+		lines = append(lines, c.Format(u))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// DiffConfig returns the field-level changes needed to turn from into to. Objects only in one configuration are
+// compared against an empty object. The rbid and object_no keys are ignored.
+func DiffConfig(from Config, to Config) ConfigDiff {
+	var diff ConfigDiff
+	seen := make(map[string]bool)
+	compare := func(o ConfigObject) {
+		if seen[o.Key()] {
+			return
+		}
+		seen[o.Key()] = true
+		fromObj, _ := from.Object(o.Request, o.Load, o.Index)
+		toObj, _ := to.Object(o.Request, o.Load, o.Index)
+		diff = append(diff, diffObject(o, fromObj.Data, toObj.Data)...)
+	}
+	for _, o := range to.Objects {
+		compare(o)
+	}
+	for _, o := range from.Objects {
+		compare(o)
+	}
+	return diff
+}
+
+func diffObject(o ConfigObject, from map[string]interface{}, to map[string]interface{}) ConfigDiff {
+	fields := make([]string, 0, len(to))
+	for f := range to {
+		if !envelopeKeys[f] {
+			fields = append(fields, f)
+		}
+	}
+	for f := range from {
+		if _, ok := to[f]; !ok && !envelopeKeys[f] {
+			fields = append(fields, f)
+		}
+	}
+	sort.Strings(fields)
+
+	var diff ConfigDiff
+	o.Data = nil
+	for _, f := range fields {
+		if !reflect.DeepEqual(from[f], to[f]) {
+			diff = append(diff, ConfigChange{Object: o, Field: f, From: from[f], To: to[f]})
+		}
+	}
+	return diff
+}
+
+// importChanges returns the changes writing o makes to an object holding data. Fields that are only in data are
+// not written, so they are not changes.
+func importChanges(o ConfigObject, data map[string]interface{}) ConfigDiff {
+	var diff ConfigDiff
+	for _, change := range diffObject(o, data, o.Data) {
+		if _, ok := o.Data[change.Field]; ok {
+			diff = append(diff, change)
+		}
+	}
+	return diff
+}
+
+// ImportConfig restores a configuration written by ExportConfig. The current configuration is read and the changes
+// the import will write are passed to confirm, and nothing is written unless it returns true. The configuration must
+// be from the same model of boiler. Only the objects that differ are written, each is read back to verify it, and a
+// *VerifyError is returned if one does not match. Objects and fields that are not in the configuration are left as
+// they are. Factory Settings are not restored.
+//
+// Writes must be allowed on the Boiler, or DryRun set, or ErrWritesDisabled is returned before anything is read.
+// Each object is written with the request it was read with, so it can be verified. ReqBoilerRestore is not used,
+// as the format it expects is not known.
+func (b Boiler) ImportConfig(c Config, confirm func(ConfigDiff) bool) ([]WriteResult, error) {
+	return b.ImportConfigContext(context.Background(), c, confirm)
+}
+
+// ImportConfigContext restores a configuration written by ExportConfig using the provided context. See ImportConfig.
+func (b Boiler) ImportConfigContext(ctx context.Context, c Config, confirm func(ConfigDiff) bool) ([]WriteResult, error) {
+	if c.Version != ConfigVersion {
+		return nil, fmt.Errorf("ibc: unsupported configuration version %d, want %d", c.Version, ConfigVersion)
+	}
+	if !b.AllowWrites && !b.DryRun {
+		return nil, ErrWritesDisabled
+	}
+	current, err := b.ExportConfigContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if c.Model != current.Model {
+		return nil, fmt.Errorf("ibc: configuration is for a %s, boiler is a %s", c.Model, current.Model)
+	}
+
+	// The confirmed diff is built from the objects that will be written, so it shows exactly what changes.
+	var diff ConfigDiff
+	var writes []ConfigObject
+	for _, o := range c.Objects {
+		if !restored(o.Request) {
+			continue
+		}
+		cur, _ := current.Object(o.Request, o.Load, o.Index)
+		changes := importChanges(o, cur.Data)
+		if len(changes) == 0 {
+			continue
+		}
+		diff = append(diff, changes...)
+		writes = append(writes, o)
+	}
+	if len(diff) == 0 {
+		return nil, nil
+	}
+	if !confirm(diff) {
+		return nil, ErrImportDeclined
+	}
+
+	var results []WriteResult
+	for _, o := range writes {
+		res, err := b.writeData(ctx, o.Request, o.Data)
+		if err != nil || !res.Sent {
+			results = append(results, res)
+			if err != nil {
+				return results, err
+			}
+			continue
+		}
+
+		got, err := b.getObject(ctx, o.Request, o.Load, o.Index)
+		if err != nil {
+			return append(results, res), err
+		}
+		if len(importChanges(o, got)) > 0 {
+			return append(results, res), &VerifyError{Request: o.Request, Written: o.Data, Read: got}
+		}
+		res.Verified = true
+		results = append(results, res)
+	}
+	return results, nil
+}
@@ -0,0 +1,255 @@
+package ibc
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// configObjects returns every settings object, tagged with rbid and object_no as the boiler does, and the boiler data.
+func configObjects() []fakeObject {
+	objects := []fakeObject{{ReqBoilerData, 0, 0, `{"rbid":0,"object_no":11,"model":"SL 20-115 G3","model_num":3,"fwversion":"2.10"}`}}
+	for _, cr := range configRequests {
+		for load := 0; load <= 4; load++ {
+			for index := 0; index < cr.indexes; index++ {
+				objects = append(objects, fakeObject{cr.request, load, index,
+					fmt.Sprintf(`{"rbid":0,"object_no":%d,"Load":%d,"Day":%d,"Value":%d}`, cr.request, load-1, index, cr.request)})
+			}
+		}
+	}
+	return objects
+}
+
+func TestExportConfig(t *testing.T) {
+	b, _, done := newFakeBoiler(configObjects()...)
+	defer done()
+
+	c, err := b.ExportConfig()
+	if err != nil {
+		t.Fatalf("ExportConfig returned error: %v", err)
+	}
+	if c.Version != ConfigVersion || c.Model != "SL 20-115 G3" || c.FirmwareVersion != "2.10" {
+		t.Errorf("Config header is incorrect, got: %+v.", c)
+	}
+	// 6 single objects, 2 per-load objects and 7 days of programmable setback for 4 loads.
+	if len(c.Objects) != 6+2*4+7*4 {
+		t.Errorf("Config object count is incorrect, got: %v, want: %v.", len(c.Objects), 6+2*4+7*4)
+	}
+	o, ok := c.Object(ReqProgSetbackData, 3, 5)
+	if !ok || o.Data["Day"] != float64(5) || o.Key() != "Programmable Setback (load 3, Friday)" {
+		t.Errorf("Programmable setback object is incorrect, got: %+v %v.", o, ok)
+	}
+	if _, ok := o.Data["rbid"]; ok {
+		t.Errorf("Exported object includes rbid, got: %v.", o.Data)
+	}
+}
+
+func TestExportConfigUnsupported(t *testing.T) {
+	b, fb, done := newFakeBoiler(configObjects()...)
+	defer done()
+	fb.set(ReqAdvancedOptionsData, 0, 0, `{}`)
+
+	c, err := b.ExportConfig()
+	if err != nil {
+		t.Fatalf("ExportConfig returned error: %v", err)
+	}
+	if _, ok := c.Object(ReqAdvancedOptionsData, 0, 0); ok || len(c.Objects) != 5+2*4+7*4 {
+		t.Errorf("ExportConfig included an unsupported object, got: %d objects.", len(c.Objects))
+	}
+}
+
+func TestImportConfig(t *testing.T) {
+	b, fb, done := newFakeBoiler(configObjects()...)
+	defer done()
+	b.AllowWrites = true
+
+	c, err := b.ExportConfig()
+	if err != nil {
+		t.Fatalf("ExportConfig returned error: %v", err)
+	}
+	o, _ := c.Object(ReqBoilerLoadSettingsData, 2, 0)
+	o.Data["Value"] = float64(99)
+
+	var diff ConfigDiff
+	_, err = b.ImportConfig(c, func(d ConfigDiff) bool {
+		diff = d
+		return false
+	})
+	if !errors.Is(err, ErrImportDeclined) {
+		t.Errorf("ImportConfig declined is incorrect, got: %v, want: %v.", err, ErrImportDeclined)
+	}
+	if len(diff) != 1 || diff[0].String() != "Load Settings (load 2) Value: 16 -> 99" {
+		t.Errorf("ImportConfig diff is incorrect, got: %v.", diff)
+	}
+	if fb.writeCount() != 0 {
+		t.Errorf("ImportConfig declined changed the boiler, got: %d writes.", fb.writeCount())
+	}
+
+	results, err := b.ImportConfig(c, func(d ConfigDiff) bool { return true })
+	if err != nil {
+		t.Fatalf("ImportConfig returned error: %v", err)
+	}
+	if len(results) != 1 || !results[0].Verified || results[0].Request != ReqBoilerLoadSettingsData {
+		t.Errorf("ImportConfig results are incorrect, got: %+v.", results)
+	}
+
+	results, err = b.ImportConfig(c, func(d ConfigDiff) bool {
+		t.Errorf("ImportConfig asked to confirm an unchanged configuration: %v", d)
+		return true
+	})
+	if err != nil || len(results) != 0 {
+		t.Errorf("ImportConfig of unchanged configuration is incorrect, got: %v %v.", results, err)
+	}
+}
+
+func TestImportConfigPartial(t *testing.T) {
+	b, fb, done := newFakeBoiler(configObjects()...)
+	defer done()
+	b.AllowWrites = true
+
+	c, err := b.ExportConfig()
+	if err != nil {
+		t.Fatalf("ExportConfig returned error: %v", err)
+	}
+	// Objects and fields missing from the configuration are not written, so they are not shown as changes.
+	var objects []ConfigObject
+	for _, o := range c.Objects {
+		if o.Request != ReqBoilerMultiSettingData {
+			objects = append(objects, o)
+		}
+	}
+	c.Objects = objects
+	o, _ := c.Object(ReqBoilerLoadSettingsData, 2, 0)
+	delete(o.Data, "Day")
+	o.Data["Value"] = float64(99)
+
+	var diff ConfigDiff
+	results, err := b.ImportConfig(c, func(d ConfigDiff) bool {
+		diff = d
+		return true
+	})
+	if len(diff) != 1 || diff[0].String() != "Load Settings (load 2) Value: 16 -> 99" {
+		t.Errorf("ImportConfig diff is incorrect, got: %v.", diff)
+	}
+	if err != nil || len(results) != 1 || !results[0].Verified || fb.writeCount() != 1 {
+		t.Errorf("ImportConfig is incorrect, got: %+v %v after %d writes, want: 1 verified write.", results, err, fb.writeCount())
+	}
+}
+
+func TestImportConfigOtherBoiler(t *testing.T) {
+	b, fb, done := newFakeBoiler(configObjects()...)
+	defer done()
+	b.AllowWrites = true
+
+	c, err := b.ExportConfig()
+	if err != nil {
+		t.Fatalf("ExportConfig returned error: %v", err)
+	}
+	// A configuration saved from boiler 1, with the keys the boiler tags each object with.
+	for _, o := range c.Objects {
+		o.Data["rbid"] = float64(1)
+		o.Data["object_no"] = float64(o.Request)
+	}
+	o, _ := c.Object(ReqBoilerSetbackData, 4, 0)
+	o.Data["Value"] = float64(99)
+
+	var diff ConfigDiff
+	results, err := b.ImportConfig(c, func(d ConfigDiff) bool {
+		diff = d
+		return true
+	})
+	if err != nil {
+		t.Fatalf("ImportConfig returned error: %v", err)
+	}
+	if len(diff) != 1 || diff[0].String() != "Setback (load 4) Value: 14 -> 99" {
+		t.Errorf("ImportConfig diff is incorrect, got: %v.", diff)
+	}
+	if len(results) != 1 || !results[0].Verified || fb.writeCount() != 1 {
+		t.Errorf("ImportConfig results are incorrect, got: %+v after %d writes.", results, fb.writeCount())
+	}
+	if got := fb.get(ReqBoilerSetbackData, 4, 0); !strings.Contains(got, `"rbid":0`) {
+		t.Errorf("ImportConfig wrote with the wrong rbid, got: %s.", got)
+	}
+}
+
+func TestImportConfigFactorySettings(t *testing.T) {
+	b, fb, done := newFakeBoiler(configObjects()...)
+	defer done()
+	b.AllowWrites = true
+
+	c, err := b.ExportConfig()
+	if err != nil {
+		t.Fatalf("ExportConfig returned error: %v", err)
+	}
+	o, _ := c.Object(ReqBoilerFactorySettingsData, 0, 0)
+	o.Data["Value"] = float64(99)
+
+	results, err := b.ImportConfig(c, func(d ConfigDiff) bool {
+		t.Errorf("ImportConfig asked to confirm a factory settings change: %v", d)
+		return true
+	})
+	if err != nil || len(results) != 0 || fb.writeCount() != 0 {
+		t.Errorf("ImportConfig of factory settings is incorrect, got: %v %v after %d writes.", results, err, fb.writeCount())
+	}
+}
+
+func TestImportConfigRequiresOptIn(t *testing.T) {
+	b, fb, done := newFakeBoiler(configObjects()...)
+	defer done()
+
+	c, err := b.ExportConfig()
+	if err != nil {
+		t.Fatalf("ExportConfig returned error: %v", err)
+	}
+	o, _ := c.Object(ReqBoilerLoadSettingsData, 2, 0)
+	o.Data["Value"] = float64(99)
+	reads := fb.readCount()
+
+	_, err = b.ImportConfig(c, func(d ConfigDiff) bool {
+		t.Errorf("ImportConfig without AllowWrites asked to confirm: %v", d)
+		return true
+	})
+	if !errors.Is(err, ErrWritesDisabled) || fb.readCount() != reads {
+		t.Errorf("ImportConfig without AllowWrites is incorrect, got: %v after %d reads, want: %v.", err, fb.readCount()-reads, ErrWritesDisabled)
+	}
+
+	b.DryRun = true
+	results, err := b.ImportConfig(c, func(d ConfigDiff) bool { return true })
+	if err != nil || len(results) != 1 || results[0].Sent || fb.writeCount() != 0 {
+		t.Errorf("ImportConfig dry run is incorrect, got: %+v %v after %d writes.", results, err, fb.writeCount())
+	}
+}
+
+func TestImportConfigVersion(t *testing.T) {
+	b, _, done := newFakeBoiler(configObjects()...)
+	defer done()
+	b.AllowWrites = true
+
+	_, err := b.ImportConfig(Config{Version: ConfigVersion + 1}, func(d ConfigDiff) bool { return true })
+	if err == nil {
+		t.Errorf("ImportConfig of a newer version did not return an error.")
+	}
+}
+
+func TestConfigChangeFormat(t *testing.T) {
+	tests := []struct {
+		change ConfigChange
+		want   string
+	}{
+		{ConfigChange{Object: ConfigObject{Request: ReqBoilerLoadSettingsData, Name: "Load Settings", Load: 2}, Field: "SetPoint", From: float64(328), To: float64(320)},
+			"Load Settings (load 2) SetPoint: 180°F -> 176°F"},
+		{ConfigChange{Object: ConfigObject{Request: ReqBoilerLoadSettingsData, Name: "Load Settings", Load: 2}, Field: "Differential", From: float64(20), To: nil},
+			"Load Settings (load 2) Differential: 9°F -> (none)"},
+		{ConfigChange{Object: ConfigObject{Request: ReqProgSetbackData, Name: "Programmable Setback", Load: 1, Index: 1}, Field: "Events",
+			From: []interface{}{}, To: []interface{}{map[string]interface{}{"Start": float64(0), "End": float64(360), "SetbackT": float64(240)}}},
+			"Programmable Setback (load 1, Monday) Events: [] -> [00:00-06:00 140°F]"},
+		{ConfigChange{Object: ConfigObject{Request: ReqBoilerStandardData, Name: "Standard Settings"}, Field: "Unknown", From: "a", To: float64(2)},
+			"Standard Settings Unknown: a -> 2"},
+	}
+	for _, test := range tests {
+		if got := test.change.Format(Imperial); got != test.want {
+			t.Errorf("ConfigChange Format is incorrect, got: %v, want: %v.", got, test.want)
+		}
+	}
+}
@@ -0,0 +1,176 @@
+package ibc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ErrErrorLogChanged is returned when the error log shrinks while it is being read, usually because it was cleared.
+// Reading it again from the start returns the current entries.
+var ErrErrorLogChanged = errors.New("ibc: error log changed while it was being read")
+
+var boilerDateLayouts = [...]string{"01/02/2006", "01/02/06", "1/2/2006", "1/2/06", "2006-01-02"}
+var boilerTimeLayouts = [...]string{"15:04:05", "15:04", "3:04:05 PM", "3:04 PM"}
+
+// ParseBoilerTime parses the Date and Time strings reported by the boiler into a time.Time in the specified location.
+func ParseBoilerTime(date string, clock string, loc *time.Location) (time.Time, error) {
+	date = strings.TrimSpace(date)
+	clock = strings.TrimSpace(clock)
+	for _, dl := range boilerDateLayouts {
+		for _, tl := range boilerTimeLayouts {
+			if t, err := time.ParseInLocation(dl+" "+tl, date+" "+clock, loc); err == nil {
+				return t, nil
+			}
+		}
+	}
+	return time.Time{}, fmt.Errorf("ibc: unable to parse boiler date %q and time %q", date, clock)
+}
+
+// ErrorLogEntry represents a single entry in the boiler error log.
+type ErrorLogEntry struct {
+	// Index is the number of the entry in the error log. Entries are numbered from 0.
+	Index int
+	// Time is when the error occurred, according to the boiler clock. It is zero if the boiler's date could not be parsed.
+	Time time.Time
+	// Description is the name of the most severe fault in the entry, or "Unknown" if no fault is recorded.
+	Description string
+	// Faults lists every fault recorded in the entry, decoded with the Boiler's FaultTable.
+	Faults Faults
+	// Data is the raw log entry.
+	Data BoilerErrorLogData
+}
+
+func newErrorLogEntry(index int, data BoilerErrorLogData, loc *time.Location, table FaultTable) ErrorLogEntry {
+	t, _ := ParseBoilerTime(data.Date, data.Time, loc)
+	faults := table.Decode(data.MinErr, data.MajErr, data.SysErr)
+	desc := "Unknown"
+	if len(faults) > 0 {
+		desc = faults[0].Description
+	}
+	return ErrorLogEntry{
+		Index:       index,
+		Time:        t,
+		Description: desc,
+		Faults:      faults,
+		Data:        data,
+	}
+}
+
+// ErrorLogIterator walks a range of the boiler error log, one request per entry.
+//
+//	it := b.ErrorLogIterator(0, n)
+//	for it.Next(ctx) {
+//		fmt.Println(it.Entry().Description)
+//	}
+//	if err := it.Err(); err != nil {
+//		log.Println(err)
+//	}
+type ErrorLogIterator struct {
+	b     Boiler
+	next  int
+	end   int
+	table FaultTable
+	entry ErrorLogEntry
+	err   error
+}
+
+// ErrorLogIterator returns an iterator over the error log entries from start up to, but not including, end.
+// Unless Boiler.FaultTable is set, the boiler's model is read before the first entry to select the fault table.
+func (b Boiler) ErrorLogIterator(start int, end int) *ErrorLogIterator {
+	return &ErrorLogIterator{b: b, next: start, end: end}
+}
+
+// Next fetches the next entry in the range. It returns false when the range is exhausted or an error occurs.
+// If the log shrinks while it is being read, Err returns an error matching ErrErrorLogChanged.
+func (it *ErrorLogIterator) Next(ctx context.Context) bool {
+	if it.err != nil || it.next >= it.end {
+		return false
+	}
+	if it.table == nil {
+		if it.table, it.err = it.b.faultTableContext(ctx); it.err != nil {
+			return false
+		}
+	}
+	data, err := it.b.GetBoilerErrLogDataContext(ctx, it.next)
+	if err != nil {
+		it.err = err
+		return false
+	}
+	// Entries do not report the size of the log, and one that no longer exists is answered with an empty object,
+	// so check the size of the log when an entry comes back empty.
+	if data == (BoilerErrorLogData{}) {
+		bld, err := it.b.GetBoilerLogDataContext(ctx)
+		if err != nil {
+			it.err = err
+			return false
+		}
+		if it.next >= bld.LogEntries {
+			it.err = fmt.Errorf("%w: entry %d requested but the log holds %d", ErrErrorLogChanged, it.next, bld.LogEntries)
+			return false
+		}
+	}
+	it.entry = newErrorLogEntry(it.next, data, it.b.location(), it.table)
+	it.next++
+	return true
+}
+
+// Entry returns the entry fetched by the last call to Next.
+func (it *ErrorLogIterator) Entry() ErrorLogEntry {
+	return it.entry
+}
+
+// Err returns the error, if any, that stopped the iteration.
+func (it *ErrorLogIterator) Err() error {
+	return it.err
+}
+
+// ErrorLog returns every entry in the boiler error log.
+func (b Boiler) ErrorLog() ([]ErrorLogEntry, error) {
+	return b.ErrorLogContext(context.Background())
+}
+
+// ErrorLogContext returns every entry in the boiler error log using the provided context.
+func (b Boiler) ErrorLogContext(ctx context.Context) ([]ErrorLogEntry, error) {
+	entries, _, err := b.ErrorLogSinceContext(ctx, 0)
+	return entries, err
+}
+
+// ErrorLogRange returns the error log entries from start up to, but not including, end.
+func (b Boiler) ErrorLogRange(start int, end int) ([]ErrorLogEntry, error) {
+	return b.ErrorLogRangeContext(context.Background(), start, end)
+}
+
+// ErrorLogRangeContext returns the error log entries from start up to, but not including, end using the provided context.
+// The entries read before an error occurs are returned along with the error.
+func (b Boiler) ErrorLogRangeContext(ctx context.Context, start int, end int) ([]ErrorLogEntry, error) {
+	entries := make([]ErrorLogEntry, 0)
+	it := b.ErrorLogIterator(start, end)
+	for it.Next(ctx) {
+		entries = append(entries, it.Entry())
+	}
+	return entries, it.Err()
+}
+
+// ErrorLogSince returns the error log entries starting at index, along with the index to pass on the next call.
+// Tools that poll the boiler can use this to fetch only the entries added since the last poll.
+func (b Boiler) ErrorLogSince(index int) ([]ErrorLogEntry, int, error) {
+	return b.ErrorLogSinceContext(context.Background(), index)
+}
+
+// ErrorLogSinceContext returns the error log entries starting at index, along with the index to pass on the next call,
+// using the provided context. If the log now holds fewer than index entries it has been cleared, and it is read from the start.
+// If it is cleared during the read, the entries read so far are returned with an error matching ErrErrorLogChanged.
+func (b Boiler) ErrorLogSinceContext(ctx context.Context, index int) ([]ErrorLogEntry, int, error) {
+	bld, err := b.GetBoilerLogDataContext(ctx)
+	if err != nil {
+		return nil, index, err
+	}
+	if index > bld.LogEntries || index < 0 {
+		index = 0
+	}
+	entries, err := b.ErrorLogRangeContext(ctx, index, bld.LogEntries)
+	return entries, index + len(entries), err
+}
@@ -0,0 +1,134 @@
+package ibc
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestParseBoilerTime(t *testing.T) {
+	want := time.Date(2018, time.December, 3, 14, 22, 0, 0, time.UTC)
+	tests := [][2]string{
+		{"12/03/2018", "14:22:00"},
+		{"12/03/18", "14:22"},
+		{"12/3/18", "2:22 PM"},
+		{"2018-12-03", "14:22:00"},
+	}
+	for _, tt := range tests {
+		got, err := ParseBoilerTime(tt[0], tt[1], time.UTC)
+		if err != nil {
+			t.Errorf("ParseBoilerTime(%q, %q) returned error: %v", tt[0], tt[1], err)
+			continue
+		}
+		if !got.Equal(want) {
+			t.Errorf("ParseBoilerTime(%q, %q) is incorrect, got: %v, want: %v.", tt[0], tt[1], got, want)
+		}
+	}
+
+	if _, err := ParseBoilerTime("00/00/00", "00:00", time.UTC); err == nil {
+		t.Error("ParseBoilerTime returned no error for an unset clock.")
+	}
+}
+
+// maxTestErrorLog is the largest error log returned by errorLogObjects.
+const maxTestErrorLog = 8
+
+// errorLogObjects returns the boiler data for a model and an error log of n entries, one day apart, each with a
+// Fan Pressure fault. Entries past the end of the log are empty objects.
+func errorLogObjects(model string, n int) []fakeObject {
+	objects := []fakeObject{
+		{ReqBoilerData, 0, 0, fmt.Sprintf(`{"rbid":0,"object_no":11,"model":%q}`, model)},
+		{ReqBoilerLogData, 0, 0, fmt.Sprintf(`{"rbid":0,"object_no":6,"LogEntries":%d}`, n)},
+	}
+	for i := 0; i < maxTestErrorLog; i++ {
+		body := `{}`
+		if i < n {
+			body = fmt.Sprintf(`{"rbid":0,"object_no":7,"Date":"12/%02d/18","Time":"08:30","MinErr":512}`, i+1)
+		}
+		objects = append(objects, fakeObject{ReqBoilerErrorLogData, 0, i, body})
+	}
+	return objects
+}
+
+func TestErrorLog(t *testing.T) {
+	b, _, done := newFakeBoiler(errorLogObjects("SL 20-115 G3", 3)...)
+	defer done()
+	b.Location = time.UTC
+
+	entries, err := b.ErrorLog()
+	if err != nil {
+		t.Fatalf("ErrorLog returned error: %v", err)
+	}
+	if len(entries) != 3 {
+		t.Fatalf("ErrorLog is incorrect, got: %d entries, want: 3.", len(entries))
+	}
+	for i, e := range entries {
+		want := time.Date(2018, time.December, i+1, 8, 30, 0, 0, time.UTC)
+		if e.Index != i || !e.Time.Equal(want) || e.Description != "Fan Pressure" {
+			t.Errorf("Entry %d is incorrect, got: %d %v %s, want: %d %v %s.", i, e.Index, e.Time, e.Description, i, want, "Fan Pressure")
+		}
+	}
+}
+
+func TestErrorLogSince(t *testing.T) {
+	b, _, done := newFakeBoiler(errorLogObjects("SL 20-115 G3", 5)...)
+	defer done()
+	b.Location = time.UTC
+
+	entries, next, err := b.ErrorLogSince(3)
+	if err != nil {
+		t.Fatalf("ErrorLogSince returned error: %v", err)
+	}
+	if len(entries) != 2 || entries[0].Index != 3 || next != 5 {
+		t.Errorf("ErrorLogSince(3) is incorrect, got: %d entries, next %d, want: 2 entries, next 5.", len(entries), next)
+	}
+
+	entries, next, err = b.ErrorLogSince(5)
+	if err != nil || len(entries) != 0 || next != 5 {
+		t.Errorf("ErrorLogSince(5) is incorrect, got: %d entries, next %d, %v, want: 0 entries, next 5.", len(entries), next, err)
+	}
+
+	// A log with fewer entries than the last index has been cleared and is read from the start.
+	entries, next, err = b.ErrorLogSince(9)
+	if err != nil || len(entries) != 5 || next != 5 {
+		t.Errorf("ErrorLogSince(9) is incorrect, got: %d entries, next %d, %v, want: 5 entries, next 5.", len(entries), next, err)
+	}
+}
+
+func TestErrorLogCleared(t *testing.T) {
+	b, fb, done := newFakeBoiler(errorLogObjects("SL 20-115 G3", 4)...)
+	defer done()
+	b.Location = time.UTC
+	fb.onRead = func(req requestObject) {
+		if req.ObjectRequest == ReqBoilerErrorLogData && req.ObjectIndex == 1 {
+			fb.setAll(errorLogObjects("SL 20-115 G3", 1)...)
+		}
+	}
+
+	entries, err := b.ErrorLog()
+	if !errors.Is(err, ErrErrorLogChanged) || len(entries) != 2 {
+		t.Errorf("ErrorLog cleared while reading is incorrect, got: %d entries, %v, want: 2 entries, %v.", len(entries), err, ErrErrorLogChanged)
+	}
+}
+
+func TestErrorLogFaultTable(t *testing.T) {
+	// No table is registered for the model, so the fault is reported as an unknown bit rather than a G3 fault.
+	b, _, done := newFakeBoiler(errorLogObjects("SL 10-85", 1)...)
+	defer done()
+	b.Location = time.UTC
+
+	entries, err := b.ErrorLog()
+	if err != nil || len(entries) != 1 {
+		t.Fatalf("ErrorLog is incorrect, got: %v %v, want: 1 entry.", entries, err)
+	}
+	if want := "Unknown Soft Error 0x0200"; entries[0].Description != want {
+		t.Errorf("Description is incorrect, got: %s, want: %s.", entries[0].Description, want)
+	}
+
+	b.FaultTable = FaultTable{{MinorErrorRegister, 0x0200, SeveritySoft, "Blower Pressure"}}
+	entries, err = b.ErrorLog()
+	if err != nil || len(entries) != 1 || entries[0].Description != "Blower Pressure" {
+		t.Errorf("ErrorLog with FaultTable is incorrect, got: %v %v, want: Blower Pressure.", entries, err)
+	}
+}
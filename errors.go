@@ -0,0 +1,158 @@
+package ibc
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrUnsupported matches an *UnsupportedError with errors.Is.
+var ErrUnsupported = errors.New("ibc: request not supported by the boiler firmware")
+
+// TransportError is returned when the boiler could not be reached or the response could not be read.
+// This usually means the boiler is offline or the network is down.
+type TransportError struct {
+	URL string
+	Err error
+}
+
+func (e *TransportError) Error() string {
+	return fmt.Sprintf("ibc: unable to reach boiler at %s: %v", e.URL, e.Err)
+}
+
+// Unwrap returns the underlying network error.
+func (e *TransportError) Unwrap() error {
+	return e.Err
+}
+
+// HTTPStatusError is returned when the boiler responds with a non-2xx HTTP status.
+type HTTPStatusError struct {
+	StatusCode int
+	Status     string
+	Body       []byte
+}
+
+func (e *HTTPStatusError) Error() string {
+	return fmt.Sprintf("ibc: boiler returned HTTP status %s", e.Status)
+}
+
+// DecodeError is returned when the boiler responds with a body that is not the expected JSON object.
+// Body holds the raw response so unexpected firmware output can be inspected.
+type DecodeError struct {
+	Body []byte
+	Err  error
+}
+
+func (e *DecodeError) Error() string {
+	return fmt.Sprintf("ibc: unable to decode boiler response: %v", e.Err)
+}
+
+// Unwrap returns the underlying JSON error.
+func (e *DecodeError) Unwrap() error {
+	return e.Err
+}
+
+// LoadMismatchError is returned when the boiler responds with data for a different load than the one requested.
+type LoadMismatchError struct {
+	Requested int
+	Returned  int
+}
+
+func (e *LoadMismatchError) Error() string {
+	return fmt.Sprintf("ibc: requested load %d but boiler returned load %d", e.Requested, e.Returned)
+}
+
+// LoadError records the failure to read the data for a single load.
+type LoadError struct {
+	Load int
+	Err  error
+}
+
+func (e *LoadError) Error() string {
+	return fmt.Sprintf("ibc: load %d: %v", e.Load, e.Err)
+}
+
+// Unwrap returns the error that caused the load to fail.
+func (e *LoadError) Unwrap() error {
+	return e.Err
+}
+
+// LoadErrors is returned when one or more loads could not be read. Functions returning LoadErrors
+// also return the data for the loads that were read successfully.
+type LoadErrors []*LoadError
+
+func (e LoadErrors) Error() string {
+	msgs := make([]string, len(e))
+	for i, le := range e {
+		msgs[i] = le.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// Is reports whether any of the individual load errors matches target.
+func (e LoadErrors) Is(target error) bool {
+	for _, le := range e {
+		if errors.Is(le, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// As finds the first individual load error that matches target.
+func (e LoadErrors) As(target interface{}) bool {
+	for _, le := range e {
+		if errors.As(le, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// Loads returns the load numbers that failed.
+func (e LoadErrors) Loads() []int {
+	loads := make([]int, len(e))
+	for i, le := range e {
+		loads[i] = le.Load
+	}
+	return loads
+}
+
+// VerifyError is returned when the value read back from the boiler after a write does not match the value written.
+type VerifyError struct {
+	Request int
+	Written interface{}
+	Read    interface{}
+}
+
+func (e *VerifyError) Error() string {
+	return fmt.Sprintf("ibc: write to object %d not applied: wrote %v, read back %v", e.Request, e.Written, e.Read)
+}
+
+// RangeError is returned when a value to be written to the boiler is outside the range the boiler accepts.
+type RangeError struct {
+	Field string
+	Value interface{}
+	Min   interface{}
+	Max   interface{}
+}
+
+func (e *RangeError) Error() string {
+	return fmt.Sprintf("ibc: %s of %v is out of range, must be between %v and %v", e.Field, e.Value, e.Min, e.Max)
+}
+
+// UnsupportedError is returned when the boiler firmware does not support a request, either because
+// Boiler.Capabilities records it as unsupported or because firmware that predates the request answered it
+// with an empty object, which would otherwise decode as zeroed data.
+type UnsupportedError struct {
+	Request int
+}
+
+func (e *UnsupportedError) Error() string {
+	return fmt.Sprintf("ibc: request %d not supported by the boiler firmware", e.Request)
+}
+
+// Is returns true for ErrUnsupported.
+func (e *UnsupportedError) Is(target error) bool {
+	return target == ErrUnsupported
+}
@@ -0,0 +1,55 @@
+package ibc
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGetDataHTTPStatusError(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "<html>Internal Error</html>", http.StatusInternalServerError)
+	}))
+	defer s.Close()
+
+	b := Boiler{BaseURL: s.URL}
+	_, err := b.GetBoilerData()
+	var statusErr *HTTPStatusError
+	if !errors.As(err, &statusErr) {
+		t.Fatalf("GetBoilerData error is incorrect, got: %v, want: *HTTPStatusError.", err)
+	}
+	if statusErr.StatusCode != http.StatusInternalServerError {
+		t.Errorf("StatusCode is incorrect, got: %d, want: %d.", statusErr.StatusCode, http.StatusInternalServerError)
+	}
+}
+
+func TestGetDataDecodeError(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("<html>Login Required</html>"))
+	}))
+	defer s.Close()
+
+	b := Boiler{BaseURL: s.URL}
+	_, err := b.GetBoilerExtDetailData()
+	var decodeErr *DecodeError
+	if !errors.As(err, &decodeErr) {
+		t.Fatalf("GetBoilerExtDetailData error is incorrect, got: %v, want: *DecodeError.", err)
+	}
+	if string(decodeErr.Body) != "<html>Login Required</html>" {
+		t.Errorf("Body is incorrect, got: %s, want: %s.", decodeErr.Body, "<html>Login Required</html>")
+	}
+}
+
+func TestGetDataTransportError(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	url := s.URL
+	s.Close()
+
+	b := Boiler{BaseURL: url}
+	_, err := b.GetBoilerData()
+	var transportErr *TransportError
+	if !errors.As(err, &transportErr) {
+		t.Fatalf("GetBoilerData error is incorrect, got: %v, want: *TransportError.", err)
+	}
+}
@@ -0,0 +1,143 @@
+package ibc
+
+import (
+	"fmt"
+	"strings"
+)
+
+// OpStatus describes what the boiler's burner sequence is currently doing, as reported in BoilerExtDetailData.
+type OpStatus int
+
+// Operating Status Constants
+const (
+	OpIdle           OpStatus = 0
+	OpPrePurge       OpStatus = 1
+	OpIgnition       OpStatus = 2
+	OpModulating     OpStatus = 3
+	OpPostPurge      OpStatus = 4
+	OpPostCirculate  OpStatus = 5
+	OpAntiCycle      OpStatus = 6
+	OpLockout        OpStatus = 7
+	OpWarmWeatherOff OpStatus = 8
+)
+
+var opStatusNames = [...]string{"Idle", "Pre-Purge", "Ignition", "Modulating", "Post-Purge", "Post-Circulate", "Anti-Cycle", "Lockout", "Warm Weather Off"}
+
+func (o OpStatus) String() string {
+	if o < 0 || int(o) >= len(opStatusNames) {
+		return fmt.Sprintf("Unknown (%d)", int(o))
+	}
+	return opStatusNames[o]
+}
+
+// Warning represents a single active warning decoded from WarnFlags.
+type Warning struct {
+	Code        int
+	Description string
+}
+
+func (w Warning) String() string {
+	return w.Description
+}
+
+// Warnings is a list of active warnings.
+type Warnings []Warning
+
+func (w Warnings) String() string {
+	if len(w) == 0 {
+		return "None"
+	}
+	s := make([]string, len(w))
+	for i, warning := range w {
+		s[i] = warning.String()
+	}
+	return strings.Join(s, ", ")
+}
+
+var warningBitMask = [...]int{0x0001, 0x0002, 0x0004, 0x0008, 0x0010, 0x0020, 0x0040, 0x0080}
+var warningNames = [...]string{"Outdoor Sensor", "Indoor Sensor", "Supply Sensor", "Return Sensor", "Low Water Pressure", "High Stack Temperature", "Tank Sensor", "Network Communication"}
+
+// DecodeWarnings returns every warning active in the specified WarnFlags value.
+// Bits that do not map to a known warning are reported as unknown warnings.
+func DecodeWarnings(warnFlags int) Warnings {
+	warnings := make(Warnings, 0)
+	known := 0
+	for i, bit := range warningBitMask {
+		known |= bit
+		if warnFlags&bit != 0 {
+			warnings = append(warnings, Warning{Code: bit, Description: warningNames[i]})
+		}
+	}
+	unknown := warnFlags &^ known
+	for bit := 1; bit <= 0x8000; bit <<= 1 {
+		if unknown&bit != 0 {
+			warnings = append(warnings, Warning{Code: bit, Description: fmt.Sprintf("Unknown Warning 0x%04X", bit)})
+		}
+	}
+	return warnings
+}
+
+// Pump bits in BoilerExtDetailData.Pumps.
+const (
+	boilerPumpBit = 0x01
+	systemPumpBit = 0x02
+	loadPumpShift = 2
+)
+
+// PumpStatus reports which of the boiler's pumps are running.
+type PumpStatus struct {
+	Boiler bool
+	System bool
+	// Loads holds the state of the pumps for loads 1 through 4.
+	Loads [4]bool
+}
+
+// DecodePumps returns the pump states in the specified Pumps value.
+func DecodePumps(pumps int) PumpStatus {
+	ps := PumpStatus{
+		Boiler: pumps&boilerPumpBit != 0,
+		System: pumps&systemPumpBit != 0,
+	}
+	for _, n := range getLoadNumbersFromBits(pumps >> loadPumpShift) {
+		ps.Loads[n-1] = true
+	}
+	return ps
+}
+
+// Load returns true if the pump for the specified load number, 1 through 4, is running.
+func (ps PumpStatus) Load(loadNum int) bool {
+	if loadNum < 1 || loadNum > len(ps.Loads) {
+		return false
+	}
+	return ps.Loads[loadNum-1]
+}
+
+// String lists the running pumps, for example "Boiler, Load 2".
+func (ps PumpStatus) String() string {
+	on := make([]string, 0, 6)
+	if ps.Boiler {
+		on = append(on, "Boiler")
+	}
+	if ps.System {
+		on = append(on, "System")
+	}
+	for i, running := range ps.Loads {
+		if running {
+			on = append(on, fmt.Sprintf("Load %d", i+1))
+		}
+	}
+	if len(on) == 0 {
+		return "Off"
+	}
+	return strings.Join(on, ", ")
+}
+
+// ActiveWarnings returns the warnings reported in WarnFlags.
+func (bedd BoilerExtDetailData) ActiveWarnings() Warnings {
+	return DecodeWarnings(bedd.WarnFlags)
+}
+
+// PumpStatus returns the pump states reported in Pumps.
+func (bedd BoilerExtDetailData) PumpStatus() PumpStatus {
+	return DecodePumps(bedd.Pumps)
+}
@@ -0,0 +1,42 @@
+package ibc
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestExtDetailBitfields(t *testing.T) {
+	var bedd BoilerExtDetailData
+	err := json.Unmarshal([]byte(`{"WarnFlags":17,"Pumps":21,"OpStatus":3}`), &bedd)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if s := bedd.ActiveWarnings().String(); s != "Outdoor Sensor, Low Water Pressure" {
+		t.Errorf("ActiveWarnings is incorrect, got: %s, want: %s.", s, "Outdoor Sensor, Low Water Pressure")
+	}
+
+	ps := bedd.PumpStatus()
+	if !ps.Boiler || ps.System || !ps.Load(1) || ps.Load(2) || !ps.Load(3) || ps.Load(4) {
+		t.Errorf("PumpStatus is incorrect, got: %+v.", ps)
+	}
+	if ps.String() != "Boiler, Load 1, Load 3" {
+		t.Errorf("PumpStatus.String is incorrect, got: %s, want: %s.", ps.String(), "Boiler, Load 1, Load 3")
+	}
+
+	if bedd.OpStatus != OpModulating || bedd.OpStatus.String() != "Modulating" {
+		t.Errorf("OpStatus is incorrect, got: %v, want: Modulating.", bedd.OpStatus)
+	}
+}
+
+func TestDecodeUnknownBits(t *testing.T) {
+	if s := DecodeWarnings(0x0400).String(); s != "Unknown Warning 0x0400" {
+		t.Errorf("DecodeWarnings is incorrect, got: %s, want: %s.", s, "Unknown Warning 0x0400")
+	}
+	if s := DecodePumps(0).String(); s != "Off" {
+		t.Errorf("DecodePumps is incorrect, got: %s, want: Off.", s)
+	}
+	if s := OpStatus(42).String(); s != "Unknown (42)" {
+		t.Errorf("OpStatus.String is incorrect, got: %s, want: %s.", s, "Unknown (42)")
+	}
+}
@@ -0,0 +1,299 @@
+package ibc
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// FaultSeverity describes how serious a fault is.
+type FaultSeverity int
+
+// Fault Severity Constants
+const (
+	// SeveritySoft faults stop the boiler until the condition clears.
+	SeveritySoft FaultSeverity = 0
+	// SeverityHard faults lock the boiler out until it is reset.
+	SeverityHard FaultSeverity = 1
+	// SeveritySystem faults are failures of the boiler controller itself.
+	SeveritySystem FaultSeverity = 2
+)
+
+var faultSeverityNames = [...]string{"Soft", "Hard", "System"}
+
+func (s FaultSeverity) String() string {
+	if s < 0 || int(s) >= len(faultSeverityNames) {
+		return "Unknown"
+	}
+	return faultSeverityNames[s]
+}
+
+// FaultRegister identifies which of the boiler's error values a fault was reported in.
+type FaultRegister int
+
+// Fault Register Constants
+const (
+	MinorErrorRegister  FaultRegister = 0
+	MajorErrorRegister  FaultRegister = 1
+	SystemErrorRegister FaultRegister = 2
+)
+
+var faultRegisterNames = [...]string{"MinErr", "MajErr", "SysErr"}
+
+func (r FaultRegister) String() string {
+	if r < 0 || int(r) >= len(faultRegisterNames) {
+		return "Unknown"
+	}
+	return faultRegisterNames[r]
+}
+
+// Fault represents a single active fault decoded from the boiler's error values.
+type Fault struct {
+	Severity FaultSeverity
+	// Register and Code identify the bit that reported the fault.
+	Register    FaultRegister
+	Code        int
+	Description string
+}
+
+func (f Fault) String() string {
+	return fmt.Sprintf("%s (%s)", f.Description, f.Severity)
+}
+
+// Faults is a list of active faults.
+type Faults []Fault
+
+func (f Faults) String() string {
+	if len(f) == 0 {
+		return "None"
+	}
+	s := make([]string, len(f))
+	for i, fault := range f {
+		s[i] = fault.String()
+	}
+	return strings.Join(s, ", ")
+}
+
+// HasSeverity returns true if any of the faults have the specified severity.
+func (f Faults) HasSeverity(severity FaultSeverity) bool {
+	for _, fault := range f {
+		if fault.Severity == severity {
+			return true
+		}
+	}
+	return false
+}
+
+// FaultBit maps a bit in one of the error values to the fault it reports. The severity is the
+// severity the boiler treats the fault with, which is not always that of the register it is reported in.
+type FaultBit struct {
+	Register    FaultRegister
+	Bit         int
+	Severity    FaultSeverity
+	Description string
+}
+
+// FaultTable lists the faults reported by a series of boilers, in the order they are reported.
+type FaultTable []FaultBit
+
+// Validate returns an error if any entry in the table is for an unknown register or does not map a single bit.
+func (t FaultTable) Validate() error {
+	for _, fb := range t {
+		if !fb.valid() {
+			return fmt.Errorf("ibc: invalid fault table entry %q: register %d, bit 0x%X", fb.Description, fb.Register, fb.Bit)
+		}
+	}
+	return nil
+}
+
+func (fb FaultBit) valid() bool {
+	return fb.Register >= 0 && int(fb.Register) < len(faultRegisterNames) &&
+		fb.Bit > 0 && fb.Bit <= 0x8000 && fb.Bit&(fb.Bit-1) == 0
+}
+
+// g3Faults lists the faults reported by G3 boilers, in the order they are reported.
+var g3Faults = FaultTable{
+	{SystemErrorRegister, 0x01, SeveritySystem, "CANbus"},
+	{SystemErrorRegister, 0x02, SeveritySystem, "CGI Task"},
+	{SystemErrorRegister, 0x04, SeveritySystem, "I2C Bus 0"},
+	{SystemErrorRegister, 0x08, SeveritySystem, "I2C Bus 1"},
+	{SystemErrorRegister, 0x10, SeveritySystem, "BACnet Task"},
+	{SystemErrorRegister, 0x20, SeveritySystem, "GPIO Expander"},
+	{SystemErrorRegister, 0x40, SeveritySystem, "LCD Module/Bus"},
+	{SystemErrorRegister, 0x80, SeveritySystem, "FRAM Module"},
+
+	{MajorErrorRegister, 0x01, SeverityHard, "Ignition Trials Exceeded"},
+	{MajorErrorRegister, 0x02, SeverityHard, "Module High Current"},
+	{MajorErrorRegister, 0x08, SeverityHard, "Low Water Cutoff"},
+	{MajorErrorRegister, 0x10, SeverityHard, "Roll Out Switch"},
+	{MajorErrorRegister, 0x20, SeverityHard, "Vent High Pressure"},
+	// Soft error bits the boiler treats as hard errors.
+	{MinorErrorRegister, 0x10, SeverityHard, "Water High Limit"},
+	{MinorErrorRegister, 0x20, SeverityHard, "Vent High Limit"},
+
+	// Hard error bit the boiler treats as a soft error.
+	{MajorErrorRegister, 0x04, SeveritySoft, "Temp. Probe Error"},
+	{MinorErrorRegister, 0x0001, SeveritySoft, "Flame Sig/Vent Blocked"},
+	{MinorErrorRegister, 0x0004, SeveritySoft, "Low RPM/Air Flow"},
+	{MinorErrorRegister, 0x0008, SeveritySoft, "No/Low Water Flow"},
+	{MinorErrorRegister, 0x0040, SeveritySoft, "Interlock 1 Open"},
+	{MinorErrorRegister, 0x0080, SeveritySoft, "Interlock 2 Open"},
+	{MinorErrorRegister, 0x0100, SeveritySoft, "Inlet Pressure Sensor"},
+	{MinorErrorRegister, 0x0200, SeveritySoft, "Fan Pressure"},
+	{MinorErrorRegister, 0x0400, SeveritySoft, "No/Low Water Flow"},
+	{MinorErrorRegister, 0x0800, SeveritySoft, "Low Module Current"},
+	{MinorErrorRegister, 0x1000, SeveritySoft, "Reversed Flow"},
+	{MinorErrorRegister, 0x2000, SeveritySoft, "See Error Log/SIM"},
+	{MinorErrorRegister, 0x4000, SeveritySoft, "Low Water Pressure"},
+	{MinorErrorRegister, 0x8000, SeveritySoft, "Max deltaT Exceeded"},
+}
+
+// DecodeFaults returns every fault active in the specified error values, system faults first,
+// then hard, then soft. Bits that do not map to a known fault are reported as unknown faults. Assumes G3 Boilers;
+// use FaultTableForModel to decode faults for other models.
+func DecodeFaults(minErr int, majErr int, sysErr int) Faults {
+	return g3Faults.Decode(minErr, majErr, sysErr)
+}
+
+var faultTables = struct {
+	sync.RWMutex
+	bySeries   map[string]FaultTable
+	byModelNum map[int]string
+}{
+	bySeries:   map[string]FaultTable{"G3": g3Faults},
+	byModelNum: map[int]string{},
+}
+
+// RegisterFaultTable sets the fault table used for a boiler series. A series is a model family such as "SL",
+// a controller generation such as "G3", or both such as "VFC G3". The most specific registered series is used.
+// An error is returned, and the table is not registered, if the table is invalid. See FaultTable.Validate.
+//
+// Only the G3 table is registered by default, as it is the only one whose bits are known. SL and VFC boilers
+// with earlier controllers report every active bit as an unknown fault until a table is registered for them.
+func RegisterFaultTable(series string, table FaultTable) error {
+	if err := table.Validate(); err != nil {
+		return err
+	}
+	family, generation := ModelSeries(series)
+	faultTables.Lock()
+	defer faultTables.Unlock()
+	faultTables.bySeries[strings.TrimSpace(family+" "+generation)] = table
+	return nil
+}
+
+// RegisterModelNum sets the series of boilers reporting the specified ModelNum. It is used when the boiler
+// does not report a Model.
+func RegisterModelNum(modelNum int, series string) {
+	faultTables.Lock()
+	defer faultTables.Unlock()
+	faultTables.byModelNum[modelNum] = series
+}
+
+// ModelSeries returns the family and controller generation of a boiler model, for example "SL" and "G3"
+// for "SL 20-115 G3". Either is empty if it can not be determined.
+func ModelSeries(model string) (family string, generation string) {
+	notLetter := func(r rune) bool { return !unicode.IsLetter(r) }
+	notDigit := func(r rune) bool { return !unicode.IsDigit(r) }
+
+	fields := strings.Fields(strings.ToUpper(model))
+	for i, f := range fields {
+		switch {
+		case len(f) > 1 && f[0] == 'G' && strings.IndexFunc(f[1:], notDigit) < 0:
+			generation = f
+		case i == 0:
+			family = f
+			if n := strings.IndexFunc(f, notLetter); n >= 0 {
+				family = f[:n]
+			}
+		}
+	}
+	return family, generation
+}
+
+// FaultTableForModel returns the fault table for the specified Model and ModelNum. The table registered for the
+// model's family and generation is used first, then the generation, then the family. If no table is registered,
+// ok is false and an empty table is returned, which reports every active bit as an unknown fault.
+func FaultTableForModel(model string, modelNum int) (table FaultTable, ok bool) {
+	faultTables.RLock()
+	defer faultTables.RUnlock()
+
+	if strings.TrimSpace(model) == "" {
+		model = faultTables.byModelNum[modelNum]
+	}
+	family, generation := ModelSeries(model)
+	for _, series := range [...]string{strings.TrimSpace(family + " " + generation), generation, family} {
+		if series == "" {
+			continue
+		}
+		if table, ok = faultTables.bySeries[series]; ok {
+			return table, true
+		}
+	}
+	return FaultTable{}, false
+}
+
+// FaultTable returns the fault table for this boiler's model. See FaultTableForModel.
+func (bd BoilerData) FaultTable() FaultTable {
+	table, _ := FaultTableForModel(bd.Model, bd.ModelNum)
+	return table
+}
+
+// registerSeverity is the severity of the faults reported by each register, used for unknown bits.
+var registerSeverity = [...]FaultSeverity{
+	MinorErrorRegister:  SeveritySoft,
+	MajorErrorRegister:  SeverityHard,
+	SystemErrorRegister: SeveritySystem,
+}
+
+// Decode returns every fault in the table active in the specified error values, system faults first,
+// then hard, then soft. Bits that are not in the table are reported as unknown faults. Invalid entries are ignored.
+func (t FaultTable) Decode(minErr int, majErr int, sysErr int) Faults {
+	values := [...]int{MinorErrorRegister: minErr, MajorErrorRegister: majErr, SystemErrorRegister: sysErr}
+	var known [len(values)]int
+	valid := make(FaultTable, 0, len(t))
+	for _, fb := range t {
+		if fb.valid() {
+			known[fb.Register] |= fb.Bit
+			valid = append(valid, fb)
+		}
+	}
+
+	faults := make(Faults, 0)
+	for _, severity := range [...]FaultSeverity{SeveritySystem, SeverityHard, SeveritySoft} {
+		for _, fb := range valid {
+			if fb.Severity == severity && values[fb.Register]&fb.Bit != 0 {
+				faults = append(faults, Fault{Severity: fb.Severity, Register: fb.Register, Code: fb.Bit, Description: fb.Description})
+			}
+		}
+		for register, value := range values {
+			if registerSeverity[register] == severity {
+				faults = append(faults, unknownFaults(FaultRegister(register), value&^known[register])...)
+			}
+		}
+	}
+	return faults
+}
+
+// unknownFaults reports each bit set in unknown as an unknown fault in the specified register.
+func unknownFaults(register FaultRegister, unknown int) Faults {
+	severity := registerSeverity[register]
+	faults := make(Faults, 0)
+	for bit := 1; bit <= 0x8000; bit <<= 1 {
+		if unknown&bit != 0 {
+			desc := fmt.Sprintf("Unknown %s Error 0x%04X", severity, bit)
+			faults = append(faults, Fault{Severity: severity, Register: register, Code: bit, Description: desc})
+		}
+	}
+	return faults
+}
+
+// Faults returns every fault active on the boiler. Assumes G3 Boilers; use BoilerData.FaultTable to decode other models.
+func (bedd BoilerExtDetailData) Faults() Faults {
+	return DecodeFaults(bedd.MinorError, bedd.MajorError, bedd.SystemError)
+}
+
+// Faults returns every fault recorded in this error log entry. Assumes G3 Boilers; use BoilerData.FaultTable to decode other models.
+func (beld BoilerErrorLogData) Faults() Faults {
+	return DecodeFaults(beld.MinErr, beld.MajErr, beld.SysErr)
+}
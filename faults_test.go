@@ -0,0 +1,192 @@
+package ibc
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+var decodeFaultsTests = []struct {
+	minErr, majErr, sysErr int
+	want                   Faults
+}{
+	{0, 0, 0, Faults{}},
+	{0x0200 | 0x1000, 0, 0, Faults{
+		{SeveritySoft, MinorErrorRegister, 0x0200, "Fan Pressure"},
+		{SeveritySoft, MinorErrorRegister, 0x1000, "Reversed Flow"},
+	}},
+	// Water High Limit and Vent High Limit are reported as soft errors but are hard faults.
+	{0x10 | 0x20 | 0x01, 0, 0, Faults{
+		{SeverityHard, MinorErrorRegister, 0x10, "Water High Limit"},
+		{SeverityHard, MinorErrorRegister, 0x20, "Vent High Limit"},
+		{SeveritySoft, MinorErrorRegister, 0x01, "Flame Sig/Vent Blocked"},
+	}},
+	// The temperature probe is reported as a hard error but is a soft fault.
+	{0, 0x04 | 0x01, 0, Faults{
+		{SeverityHard, MajorErrorRegister, 0x01, "Ignition Trials Exceeded"},
+		{SeveritySoft, MajorErrorRegister, 0x04, "Temp. Probe Error"},
+	}},
+	{0x0002, 0x40, 0x02, Faults{
+		{SeveritySystem, SystemErrorRegister, 0x02, "CGI Task"},
+		{SeverityHard, MajorErrorRegister, 0x40, "Unknown Hard Error 0x0040"},
+		{SeveritySoft, MinorErrorRegister, 0x0002, "Unknown Soft Error 0x0002"},
+	}},
+}
+
+func TestDecodeFaults(t *testing.T) {
+	for _, tt := range decodeFaultsTests {
+		got := DecodeFaults(tt.minErr, tt.majErr, tt.sysErr)
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("DecodeFaults(0x%X, 0x%X, 0x%X) is incorrect, got: %v, want: %v.", tt.minErr, tt.majErr, tt.sysErr, got, tt.want)
+		}
+	}
+}
+
+func TestFaultsString(t *testing.T) {
+	f := DecodeFaults(0x0200, 0x01, 0)
+	want := "Ignition Trials Exceeded (Hard), Fan Pressure (Soft)"
+	if f.String() != want {
+		t.Errorf("Faults.String is incorrect, got: %s, want: %s.", f.String(), want)
+	}
+	if !f.HasSeverity(SeverityHard) || f.HasSeverity(SeveritySystem) {
+		t.Errorf("Faults.HasSeverity is incorrect for %v.", f)
+	}
+}
+
+func TestModelSeries(t *testing.T) {
+	tests := []struct{ model, family, generation string }{
+		{"SL 20-115 G3", "SL", "G3"},
+		{"VFC 15-150 G3", "VFC", "G3"},
+		{"sl 10-85", "SL", ""},
+		{"VFC15-150", "VFC", ""},
+		{"G3", "", "G3"},
+		{"", "", ""},
+	}
+	for _, tt := range tests {
+		family, generation := ModelSeries(tt.model)
+		if family != tt.family || generation != tt.generation {
+			t.Errorf("ModelSeries(%q) is incorrect, got: %q %q, want: %q %q.", tt.model, family, generation, tt.family, tt.generation)
+		}
+	}
+}
+
+// faultTableTests checks the mapping of each bit for a series. A zero Fault means the bit is reported as unknown.
+var faultTableTests = []struct {
+	model string
+	bits  map[FaultRegister]map[int]string
+}{
+	{"SL 20-115 G3", map[FaultRegister]map[int]string{
+		MinorErrorRegister:  {0x0001: "Flame Sig/Vent Blocked", 0x0002: "", 0x0010: "Water High Limit", 0x0400: "No/Low Water Flow", 0x8000: "Max deltaT Exceeded"},
+		MajorErrorRegister:  {0x01: "Ignition Trials Exceeded", 0x04: "Temp. Probe Error", 0x20: "Vent High Pressure", 0x40: ""},
+		SystemErrorRegister: {0x01: "CANbus", 0x80: "FRAM Module"},
+	}},
+	{"VFC 15-150 G3", map[FaultRegister]map[int]string{
+		MinorErrorRegister:  {0x0200: "Fan Pressure", 0x1000: "Reversed Flow"},
+		MajorErrorRegister:  {0x08: "Low Water Cutoff", 0x10: "Roll Out Switch"},
+		SystemErrorRegister: {0x10: "BACnet Task"},
+	}},
+	// Non-G3 boilers have no registered table, so every bit is reported as unknown rather than guessed.
+	{"SL 10-85", map[FaultRegister]map[int]string{
+		MinorErrorRegister:  {0x0001: "", 0x0200: ""},
+		MajorErrorRegister:  {0x01: ""},
+		SystemErrorRegister: {0x01: ""},
+	}},
+	{"VFC 45-225", map[FaultRegister]map[int]string{
+		MinorErrorRegister: {0x1000: ""},
+		MajorErrorRegister: {0x20: ""},
+	}},
+}
+
+func TestFaultTableForModel(t *testing.T) {
+	for _, tt := range faultTableTests {
+		table, _ := FaultTableForModel(tt.model, 0)
+		for register, bits := range tt.bits {
+			for bit, desc := range bits {
+				values := [3]int{}
+				values[register] = bit
+				faults := table.Decode(values[MinorErrorRegister], values[MajorErrorRegister], values[SystemErrorRegister])
+				if len(faults) != 1 {
+					t.Errorf("%s %s 0x%04X is incorrect, got: %v, want: a single fault.", tt.model, register, bit, faults)
+					continue
+				}
+				if desc == "" {
+					desc = fmt.Sprintf("Unknown %s Error 0x%04X", registerSeverity[register], bit)
+				}
+				if faults[0].Description != desc || faults[0].Code != bit || faults[0].Register != register {
+					t.Errorf("%s %s 0x%04X is incorrect, got: %+v, want: %s.", tt.model, register, bit, faults[0], desc)
+				}
+			}
+		}
+	}
+}
+
+// restoreFaultTables returns a function that restores the registered fault tables to their current state, so tests
+// that register tables do not leave them behind.
+func restoreFaultTables() func() {
+	faultTables.Lock()
+	defer faultTables.Unlock()
+	bySeries := make(map[string]FaultTable, len(faultTables.bySeries))
+	for series, table := range faultTables.bySeries {
+		bySeries[series] = table
+	}
+	byModelNum := make(map[int]string, len(faultTables.byModelNum))
+	for modelNum, series := range faultTables.byModelNum {
+		byModelNum[modelNum] = series
+	}
+	return func() {
+		faultTables.Lock()
+		defer faultTables.Unlock()
+		faultTables.bySeries = bySeries
+		faultTables.byModelNum = byModelNum
+	}
+}
+
+func TestRegisterFaultTable(t *testing.T) {
+	defer restoreFaultTables()()
+
+	if _, ok := FaultTableForModel("TST 10-50", 0); ok {
+		t.Fatal("FaultTableForModel found a table for an unregistered series.")
+	}
+
+	if err := RegisterFaultTable("TST", FaultTable{{MinorErrorRegister, 0x0002, SeveritySoft, "Test Fault"}}); err != nil {
+		t.Fatalf("RegisterFaultTable returned error: %v", err)
+	}
+	RegisterModelNum(9001, "TST 10-50")
+
+	bd := BoilerData{ModelNum: 9001}
+	faults := bd.FaultTable().Decode(0x0002, 0, 0)
+	if len(faults) != 1 || faults[0].Description != "Test Fault" {
+		t.Errorf("Registered fault table is incorrect, got: %v, want: Test Fault.", faults)
+	}
+
+	// G3 boilers of the family still use the more specific G3 table.
+	if table, ok := FaultTableForModel("TST 10-50 G3", 0); !ok || len(table) != len(g3Faults) {
+		t.Error("FaultTableForModel did not prefer the generation table.")
+	}
+}
+
+func TestFaultTableInvalid(t *testing.T) {
+	defer restoreFaultTables()()
+
+	bad := FaultTable{
+		{FaultRegister(3), 0x0001, SeveritySoft, "Bad Register"},
+		{MinorErrorRegister, 0x0003, SeveritySoft, "Two Bits"},
+		{MinorErrorRegister, 0x0002, SeveritySoft, "Test Fault"},
+	}
+	if err := RegisterFaultTable("BAD", bad); err == nil {
+		t.Error("RegisterFaultTable returned no error for an invalid table.")
+	}
+	if _, ok := FaultTableForModel("BAD 10-50", 0); ok {
+		t.Error("RegisterFaultTable registered an invalid table.")
+	}
+
+	// Invalid entries are ignored rather than panicking, and their bits are reported as unknown.
+	got := bad.Decode(0x0003, 0, 0)
+	want := Faults{
+		{SeveritySoft, MinorErrorRegister, 0x0002, "Test Fault"},
+		{SeveritySoft, MinorErrorRegister, 0x0001, "Unknown Soft Error 0x0001"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Decode is incorrect, got: %v, want: %v.", got, want)
+	}
+}
@@ -5,6 +5,7 @@ require (
 	github.com/jessevdk/go-flags v1.4.0
 	gopkg.in/alexcesaro/quotedprintable.v3 v3.0.0-20150716171945-2caba252f4dc // indirect
 	gopkg.in/gomail.v2 v2.0.0-20160411212932-81ebce5c23df
+	gopkg.in/yaml.v2 v2.4.0
 )
 
-go 1.11
+go 1.13
@@ -1,25 +1,17 @@
 package ibc
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
+	"math"
 	"net/http"
 	"strings"
+	"time"
 )
 
-// System Status Constants
-const (
-	Standby      = 0
-	Purging      = 1
-	Igniting     = 2
-	Heating      = 3
-	Circulating  = 4
-	Error        = 5
-	Initializing = 6
-)
-
-// G3 soft errors. TODO: Updated to handle non-G3 soft errors.
+// G3 errors, used by GetErrorString. See FaultTableForModel for other models.
 var hardErrorsBitMask = [...]int{0x01, 0x10, 0x20, 0x02, 0x04, 0x08}
 var hardErrors = [...]string{"Ignition Trials Exceeded", "Roll Out Switch", "Low Water Cutoff", "Module High Current", "Sec/Indoor Sensor", "Low Water Cutoff"}
 var softErrors1BitMask = [...]int{0x0001, 0x0004, 0x0008, 0x0010, 0x0020, 0x0040, 0x0080}
@@ -32,21 +24,42 @@ var systemErrors = [...]string{"CANbus", "CGI Task", "I2C Bus 0", "I2C Bus 1", "
 // Boiler represents a specific IBC Boiler to interact with.
 type Boiler struct {
 	BaseURL string
+	// BoilerNum selects the boiler on a cascade network that requests are made for. The boiler at BaseURL is 0.
+	// Use Unit or Units to get a Boiler for each networked boiler.
+	BoilerNum int
+	// Client is the HTTP client used to query the boiler. If nil, http.DefaultClient is used.
+	Client *http.Client
+	// Timeout limits the duration of each request made to the boiler. Zero means no limit.
+	Timeout time.Duration
+	// UserAgent is sent as the User-Agent header on each request, if set.
+	UserAgent string
+	// Location is the time zone the boiler clock is set to. If nil, time.Local is used.
+	Location *time.Location
+	// FaultTable decodes the faults in the boiler error log. If nil, the table for the boiler's model is used.
+	// See BoilerData.FaultTable.
+	FaultTable FaultTable
+	// AllowWrites must be set for methods that change boiler settings to send requests. Otherwise they return ErrWritesDisabled.
+	AllowWrites bool
+	// DryRun causes methods that change boiler settings to return the request they would send without sending it.
+	DryRun bool
+	// Capabilities, if set, causes requests it records as unsupported to return an *UnsupportedError without
+	// querying the boiler. See ProbeCapabilities.
+	Capabilities *Capabilities
 }
 
 // BoilerStatusData represents the data returned from the ReqBoilerStatusData request.
 type BoilerStatusData struct {
 	//"rbid": 0
 	//"object_no": 3
-	Status int `json:"status"`
+	Status Status `json:"status"`
 	// MBH is Thousands of BTUs per hour
-	MBH                     int `json:"mbh"`
-	SupplyTemp              int `json:"supplyT"`
-	ReturnTemp              int `json:"returnT"`
-	SecondaryTemp           int `json:"secondaryT"`
-	DomesticWaterHeaterTemp int `json:"dhwT"`
-	PSIG                    int `json:"psig"`
-	Warning                 int `json:"warning"`
+	MBH                     int         `json:"mbh"`
+	SupplyTemp              Temperature `json:"supplyT"`
+	ReturnTemp              Temperature `json:"returnT"`
+	SecondaryTemp           Temperature `json:"secondaryT"`
+	DomesticWaterHeaterTemp Temperature `json:"dhwT"`
+	PSIG                    Pressure    `json:"psig"`
+	Warning                 int         `json:"warning"`
 }
 
 // BoilerLogData represents the data returned by the ReqBoilerLogData request
@@ -74,47 +87,47 @@ type BoilerErrorLogData struct {
 	//"rbid": 0
 	//"object_no": 7
 	//log_no:
-	Time           string `json:"Time"`
-	Date           string `json:"Date"`
-	MinErr         int    `json:"MinErr"`
-	MajErr         int    `json:"MajErr"`
-	SysErr         int    `json:"SysErr"`
-	HeatOut        int    `json:"HeatOut"`
-	FanRPM         int    `json:"FanRPM"`
-	InletTemp      int    `json:"InletTemp"`
-	OutletTemp     int    `json:"OutletTemp"`
-	BoardTemp      int    `json:"BoardTemp"`
-	DiffPressure   int    `json:"DiffPressure"`
-	InletTRate     int    `json:"InletTRate"`
-	OutletTRate    int    `json:"OutletTRate"`
-	InletPressure  int    `json:"InletPressure"`
-	OutletPressure int    `json:"OutletPressure"`
-	FlameSense     int    `json:"FlameSense"`
-	SIMFlame       int    `json:"SIM_Flame"`
-	SIMStatus      int    `json:"SIM_Status"`
-	FanDutyCycle   int    `json:"FanDutyCycle"`
-	BVGauge        int    `json:"BV_Gauge"`
+	Time           string      `json:"Time"`
+	Date           string      `json:"Date"`
+	MinErr         int         `json:"MinErr"`
+	MajErr         int         `json:"MajErr"`
+	SysErr         int         `json:"SysErr"`
+	HeatOut        int         `json:"HeatOut"`
+	FanRPM         int         `json:"FanRPM"`
+	InletTemp      Temperature `json:"InletTemp"`
+	OutletTemp     Temperature `json:"OutletTemp"`
+	BoardTemp      Temperature `json:"BoardTemp"`
+	DiffPressure   Pressure    `json:"DiffPressure"`
+	InletTRate     int         `json:"InletTRate"`
+	OutletTRate    int         `json:"OutletTRate"`
+	InletPressure  Pressure    `json:"InletPressure"`
+	OutletPressure Pressure    `json:"OutletPressure"`
+	FlameSense     int         `json:"FlameSense"`
+	SIMFlame       int         `json:"SIM_Flame"`
+	SIMStatus      int         `json:"SIM_Status"`
+	FanDutyCycle   int         `json:"FanDutyCycle"`
+	BVGauge        int         `json:"BV_Gauge"`
 }
 
 // BoilerData represents the data returend by the ReqBoilerData request
 type BoilerData struct {
 	//"rbid": 0
 	//"object_no": 11,
-	Status          int    `json:"status"`
-	Master          int    `json:"master"`
-	NetMaster       int    `json:"net_master"`
-	Warnings        int    `json:"warnings"`
-	Imperial        int    `json:"imperial"`
-	OnTime          int    `json:"ontime"`
-	BoilerID        int    `json:"boiler_id"`
-	DIMTime         int    `json:"dim_time"`
-	Configured      int    `json:"configured"`
-	ModelNum        int    `json:"model_num"`
-	DesignT         int    `json:"designT"`
-	Model           string `json:"model"`
-	FirmwareVersion string `json:"fwversion"`
-	FirmwareDate    string `json:"fwdate"`
-	SICCModule      bool   `json:"sicc_module"`
+	Status          Status      `json:"status"`
+	Master          int         `json:"master"`
+	NetMaster       int         `json:"net_master"`
+	Warnings        int         `json:"warnings"`
+	Imperial        int         `json:"imperial"`
+	OnTime          int         `json:"ontime"`
+	BoilerID        int         `json:"boiler_id"`
+	DIMTime         int         `json:"dim_time"`
+	Configured      int         `json:"configured"`
+	ModelNum        int         `json:"model_num"`
+	DesignT         Temperature `json:"designT"`
+	Model           string      `json:"model"`
+	FirmwareVersion string      `json:"fwversion"`
+	FirmwareDate    string      `json:"fwdate"`
+	SICCModule      bool        `json:"sicc_module"`
 }
 
 // BoilerStandardData represnets the data returned by the ReqBoilerStandardData request.
@@ -141,52 +154,95 @@ type BoilerStandardData struct {
 type BoilerExtDetailData struct {
 	// "rbid": 0
 	// "object_no": 19
-	BoilerID       int     `json:"BoilerID"`
-	Status         string  `json:"Status"`
-	Warnings       string  `json:"Warnings"`
-	Errors         string  `json:"Errors"`
-	MBH            int     `json:"MBH"`
-	SupplyTemp     int     `json:"SupplyT"`
-	ReturnTemp     int     `json:"ReturnT"`
-	TargetTemp     int     `json:"TargetT"`
-	StackTemp      int     `json:"StackT"`
-	AirTemp        int     `json:"AirT"`
-	IndoorTemp     int     `json:"IndoorT"`
-	OutdoorTemp    int     `json:"OutdoorT"`
-	SecondaryTemp  int     `json:"SecondaryT"`
-	TankTemp       int     `json:"TankT"`
-	InletPressure  float64 `json:"InletPressure"`
-	OutletPressure float64 `json:"OutletPressure"`
-	DeltaPressure  float64 `json:"DeltaPressure"`
-	Servicing      int     `json:"Servicing"`
-	Cycles         int     `json:"Cycles"`
-	MajorError     int     `json:"MajorError"`
-	MinorError     int     `json:"MinorError"`
-	SystemError    int     `json:"SystemError"`
-	WarnFlags      int     `json:"WarnFlags"`
-	Pumps          int     `json:"Pumps"`
-	OpStatus       int     `json:"OpStatus"`
+	BoilerID       int         `json:"BoilerID"`
+	Status         string      `json:"Status"`
+	Warnings       string      `json:"Warnings"`
+	Errors         string      `json:"Errors"`
+	MBH            int         `json:"MBH"`
+	SupplyTemp     Temperature `json:"SupplyT"`
+	ReturnTemp     Temperature `json:"ReturnT"`
+	TargetTemp     Temperature `json:"TargetT"`
+	StackTemp      Temperature `json:"StackT"`
+	AirTemp        Temperature `json:"AirT"`
+	IndoorTemp     Temperature `json:"IndoorT"`
+	OutdoorTemp    Temperature `json:"OutdoorT"`
+	SecondaryTemp  Temperature `json:"SecondaryT"`
+	TankTemp       Temperature `json:"TankT"`
+	InletPressure  Pressure    `json:"InletPressure"`
+	OutletPressure Pressure    `json:"OutletPressure"`
+	DeltaPressure  Pressure    `json:"DeltaPressure"`
+	Servicing      int         `json:"Servicing"`
+	Cycles         int         `json:"Cycles"`
+	MajorError     int         `json:"MajorError"`
+	MinorError     int         `json:"MinorError"`
+	SystemError    int         `json:"SystemError"`
+	WarnFlags      int         `json:"WarnFlags"`
+	Pumps          int         `json:"Pumps"`
+	OpStatus       OpStatus    `json:"OpStatus"`
+}
+
+// ServicingState describes the mode the boiler reports in BoilerExtDetailData.Servicing.
+type ServicingState int
+
+// Servicing State Constants
+const (
+	// ServicingNormal means Servicing holds the servicing, circulating and calling load numbers.
+	ServicingNormal ServicingState = 0
+	// ServicingRemote means the boiler is controlled by a remote (external) call for heat.
+	ServicingRemote ServicingState = 1
+	// ServicingSummerOff means space heating is off for the summer.
+	ServicingSummerOff ServicingState = 2
+)
+
+const (
+	servicingRemote    = 0xFFFF
+	servicingSummerOff = 0xF000
+)
+
+var servicingStateNames = [...]string{"Normal", "Remote", "Summer Off"}
+
+func (s ServicingState) String() string {
+	if s < 0 || int(s) >= len(servicingStateNames) {
+		return "Unknown"
+	}
+	return servicingStateNames[s]
+}
+
+// ServicingState returns the mode the boiler is servicing loads in.
+func (bedd BoilerExtDetailData) ServicingState() ServicingState {
+	switch {
+	case bedd.Servicing == servicingRemote:
+		return ServicingRemote
+	case bedd.Servicing&servicingSummerOff == servicingSummerOff:
+		return ServicingSummerOff
+	}
+	return ServicingNormal
 }
 
 // ServicingLoadNumbers returns the load numbers the boiler is currently servicing.
+// No loads are returned unless the ServicingState is ServicingNormal.
 func (bedd BoilerExtDetailData) ServicingLoadNumbers() []int {
-	// TODO: Remote (0xFFFF) and Summer Off (0xF000)
-	s := bedd.Servicing & 0xF
-	return getLoadNumbersFromBits(s)
+	return bedd.servicingLoadNumbers(0)
 }
 
 // CirculatingLoadNumbers returns the load numbers the boiler is currently circulating.
+// No loads are returned unless the ServicingState is ServicingNormal.
 func (bedd BoilerExtDetailData) CirculatingLoadNumbers() []int {
-	s := bedd.Servicing
-	s >>= 4
-	s &= 0xF
-	return getLoadNumbersFromBits(s)
+	return bedd.servicingLoadNumbers(4)
 }
 
 // CallingLoadNumbers returns the load numbers that is currently calling for heat but is not being serviced.
+// No loads are returned unless the ServicingState is ServicingNormal.
 func (bedd BoilerExtDetailData) CallingLoadNumbers() []int {
+	return bedd.servicingLoadNumbers(8)
+}
+
+func (bedd BoilerExtDetailData) servicingLoadNumbers(shift uint) []int {
+	if bedd.ServicingState() != ServicingNormal {
+		return getLoadNumbersFromBits(0)
+	}
 	s := bedd.Servicing
-	s >>= 8
+	s >>= shift
 	s &= 0xF
 	return getLoadNumbersFromBits(s)
 }
@@ -195,29 +251,29 @@ func (bedd BoilerExtDetailData) CallingLoadNumbers() []int {
 type BoilerFactoryData struct {
 	//"rbid": 0
 	//"object_no": 20
-	InletP     int `json:"InletP"`
-	OutletP    int `json:"OutletP"`
-	DeltaP     int `json:"DeltaP"`
-	FlowRate   int `json:"FlowRate"`
-	FanSpeed   int `json:"FanSpeed"`
-	FanDuty    int `json:"FanDuty"`
-	FanTarget  int `json:"FanTarget"`
-	RequiredP  int `json:"RequiredP"`
-	FanP       int `json:"FanP"`
-	OffsetP    int `json:"OffsetP"`
-	VentFactor int `json:"VentFactor"`
-	VarDuty    int `json:"VarDuty"`
-	Responding int `json:"Responding"`
-	Firing     int `json:"Firing"`
-	Available  int `json:"Available"`
-	FCurrent   int `json:"F_Current"`
-	HeatOut    int `json:"HeatOut"`
-	FanHeatOut int `json:"FanHeatOut"`
-	InletT     int `json:"InletT"`
-	OutletT    int `json:"OutletT"`
-	StackT     int `json:"StackT"`
-	RPMLimit   int `json:"RPMLimit"`
-	SICCFlame  int `json:"SICC_Flame"`
+	InletP     Pressure    `json:"InletP"`
+	OutletP    Pressure    `json:"OutletP"`
+	DeltaP     Pressure    `json:"DeltaP"`
+	FlowRate   int         `json:"FlowRate"`
+	FanSpeed   int         `json:"FanSpeed"`
+	FanDuty    int         `json:"FanDuty"`
+	FanTarget  int         `json:"FanTarget"`
+	RequiredP  int         `json:"RequiredP"`
+	FanP       int         `json:"FanP"`
+	OffsetP    int         `json:"OffsetP"`
+	VentFactor int         `json:"VentFactor"`
+	VarDuty    int         `json:"VarDuty"`
+	Responding int         `json:"Responding"`
+	Firing     int         `json:"Firing"`
+	Available  int         `json:"Available"`
+	FCurrent   int         `json:"F_Current"`
+	HeatOut    int         `json:"HeatOut"`
+	FanHeatOut int         `json:"FanHeatOut"`
+	InletT     Temperature `json:"InletT"`
+	OutletT    Temperature `json:"OutletT"`
+	StackT     Temperature `json:"StackT"`
+	RPMLimit   int         `json:"RPMLimit"`
+	SICCFlame  int         `json:"SICC_Flame"`
 }
 
 // GetLoadTypeName returns the name of the specified load type. Pass in the value of Load1Type as the parameter.
@@ -229,21 +285,26 @@ func (bsd BoilerStandardData) GetLoadTypeName(loadType int) string {
 type LoadStatusData struct {
 	// "rbid": 0
 	// "object_no": 32
-	Load         int `json:"Load"`
-	Type         int `json:"Type"`
-	HeatOut      int `json:"HeatOut"`
-	SupplyTemp   int `json:"SupplyT"`
-	ReturnTemp   int `json:"ReturnT"`
-	BoilerMax    int `json:"BoilerMax"`
-	BoilerDiff   int `json:"BoilerDiff"`
-	Cycles       int `json:"Cycles"`
-	Priority     int `json:"Priority"`
-	Temperature1 int `json:"Temperature1"`
-	Temperature2 int `json:"Temperature2"`
-	Temperature3 int `json:"Temperature3"`
-	Temperature4 int `json:"Temperature4"`
-	Temperature5 int `json:"Temperature5"`
-	Temperature6 int `json:"Temperature6"`
+	Load         int              `json:"Load"`
+	Type         int              `json:"Type"`
+	HeatOut      int              `json:"HeatOut"`
+	SupplyTemp   Temperature      `json:"SupplyT"`
+	ReturnTemp   Temperature      `json:"ReturnT"`
+	BoilerMax    Temperature      `json:"BoilerMax"`
+	BoilerDiff   TemperatureDelta `json:"BoilerDiff"`
+	Cycles       int              `json:"Cycles"`
+	Priority     int              `json:"Priority"`
+	Temperature1 Temperature      `json:"Temperature1"`
+	Temperature2 Temperature      `json:"Temperature2"`
+	Temperature3 Temperature      `json:"Temperature3"`
+	Temperature4 Temperature      `json:"Temperature4"`
+	Temperature5 Temperature      `json:"Temperature5"`
+	Temperature6 Temperature      `json:"Temperature6"`
+}
+
+// LoadNumber returns the 1 based load number for this Load. The boiler reports Load starting at 0.
+func (lsd LoadStatusData) LoadNumber() int {
+	return lsd.Load + 1
 }
 
 // LoadTypeName returns the name of the LoadType for this Load.
@@ -287,15 +348,18 @@ const (
 	ReqPasswordData              = 99
 )
 
-// TempAsF returns the specified temperature in Fahrenheit. By default, all temperatures returned by the API are Celcius * 4.
+// TempAsF returns the specified temperature in Fahrenheit, rounded to the nearest degree. By default, all temperatures returned by the API are Celcius * 4.
+//
+// Deprecated: Use Temperature.F.
 func (b Boiler) TempAsF(temp int) int {
-	t := ((temp * 9) / 5) + (4 * 32)
-	return t / 4
+	return int(math.Round(Temperature(temp).F()))
 }
 
 // TempAsC returns the specified temperature in Celsius. By default, all temperatures returned by the API are Celsius * 4.
+//
+// Deprecated: Use Temperature.C.
 func (b Boiler) TempAsC(temp int) float32 {
-	return float32(temp) / float32(4)
+	return float32(Temperature(temp).C())
 }
 
 type requestObject struct {
@@ -310,88 +374,157 @@ var loadNames = [...]string{"Off", "DHW", "Reset Heating", "Set Point", "Externa
 
 // GetData queries the boiler and returns a map representing the response.
 func (b Boiler) GetData(requestNumber int) (interface{}, error) {
-	reqObj := requestObject{ObjectNum: 100, ObjectRequest: requestNumber, BoilerNum: 0}
+	return b.GetDataContext(context.Background(), requestNumber)
+}
+
+// GetDataContext queries the boiler using the provided context and returns a map representing the response.
+func (b Boiler) GetDataContext(ctx context.Context, requestNumber int) (interface{}, error) {
+	reqObj := requestObject{ObjectNum: 100, ObjectRequest: requestNumber, BoilerNum: b.BoilerNum}
 	var respObj interface{}
-	return respObj, b.getData(reqObj, &respObj)
+	return respObj, b.getData(ctx, reqObj, &respObj)
 }
 
 // GetDataForLoad queries the boiler about data for a specific load and returns a map representing the response.
 func (b Boiler) GetDataForLoad(requestNumber int, loadNumber int) (interface{}, error) {
-	reqObj := requestObject{ObjectNum: 100, ObjectRequest: requestNumber, BoilerNum: 0, LoadNum: loadNumber}
+	return b.GetDataForLoadContext(context.Background(), requestNumber, loadNumber)
+}
+
+// GetDataForLoadContext queries the boiler about data for a specific load using the provided context and returns a map representing the response.
+func (b Boiler) GetDataForLoadContext(ctx context.Context, requestNumber int, loadNumber int) (interface{}, error) {
+	reqObj := requestObject{ObjectNum: 100, ObjectRequest: requestNumber, BoilerNum: b.BoilerNum, LoadNum: loadNumber}
 	var respObj interface{}
-	return respObj, b.getData(reqObj, &respObj)
+	return respObj, b.getData(ctx, reqObj, &respObj)
 }
 
 // GetBoilerStatusData returns the BoilerStatusData for the current boiler.
 func (b Boiler) GetBoilerStatusData() (BoilerStatusData, error) {
-	reqObj := requestObject{ObjectNum: 100, ObjectRequest: ReqBoilerStatusData, BoilerNum: 0, LoadNum: 0}
+	return b.GetBoilerStatusDataContext(context.Background())
+}
+
+// GetBoilerStatusDataContext returns the BoilerStatusData for the current boiler using the provided context.
+func (b Boiler) GetBoilerStatusDataContext(ctx context.Context) (BoilerStatusData, error) {
+	reqObj := requestObject{ObjectNum: 100, ObjectRequest: ReqBoilerStatusData, BoilerNum: b.BoilerNum, LoadNum: 0}
 	var respObj = BoilerStatusData{}
-	return respObj, b.getData(reqObj, &respObj)
+	return respObj, b.getData(ctx, reqObj, &respObj)
 }
 
 // GetBoilerLogData returns the BoilerStatusData for the current boiler.
 func (b Boiler) GetBoilerLogData() (BoilerLogData, error) {
-	reqObj := requestObject{ObjectNum: 100, ObjectRequest: ReqBoilerLogData, BoilerNum: 0, LoadNum: 0}
+	return b.GetBoilerLogDataContext(context.Background())
+}
+
+// GetBoilerLogDataContext returns the BoilerLogData for the current boiler using the provided context.
+func (b Boiler) GetBoilerLogDataContext(ctx context.Context) (BoilerLogData, error) {
+	reqObj := requestObject{ObjectNum: 100, ObjectRequest: ReqBoilerLogData, BoilerNum: b.BoilerNum, LoadNum: 0}
 	var respObj = BoilerLogData{}
-	return respObj, b.getData(reqObj, &respObj)
+	return respObj, b.getData(ctx, reqObj, &respObj)
 }
 
 // GetBoilerErrLogData returns the BoilerErrorLogData for the specified logEntryNumber.
 func (b Boiler) GetBoilerErrLogData(logEntryNumber int) (BoilerErrorLogData, error) {
-	reqObj := requestObject{ObjectNum: 100, ObjectRequest: ReqBoilerErrorLogData, BoilerNum: 0, ObjectIndex: logEntryNumber}
+	return b.GetBoilerErrLogDataContext(context.Background(), logEntryNumber)
+}
+
+// GetBoilerErrLogDataContext returns the BoilerErrorLogData for the specified logEntryNumber using the provided context.
+func (b Boiler) GetBoilerErrLogDataContext(ctx context.Context, logEntryNumber int) (BoilerErrorLogData, error) {
+	reqObj := requestObject{ObjectNum: 100, ObjectRequest: ReqBoilerErrorLogData, BoilerNum: b.BoilerNum, ObjectIndex: logEntryNumber}
 	var respObj = BoilerErrorLogData{}
-	return respObj, b.getData(reqObj, &respObj)
+	return respObj, b.getData(ctx, reqObj, &respObj)
 }
 
 // GetBoilerData returns the BoilerStatusData for the current boiler.
 func (b Boiler) GetBoilerData() (BoilerData, error) {
-	reqObj := requestObject{ObjectNum: 100, ObjectRequest: ReqBoilerData, BoilerNum: 0, LoadNum: 0}
+	return b.GetBoilerDataContext(context.Background())
+}
+
+// GetBoilerDataContext returns the BoilerData for the current boiler using the provided context.
+func (b Boiler) GetBoilerDataContext(ctx context.Context) (BoilerData, error) {
+	reqObj := requestObject{ObjectNum: 100, ObjectRequest: ReqBoilerData, BoilerNum: b.BoilerNum, LoadNum: 0}
 	var respObj = BoilerData{}
-	return respObj, b.getData(reqObj, &respObj)
+	return respObj, b.getData(ctx, reqObj, &respObj)
 }
 
 // GetBoilerStandardData returns the BoilerStandardData response for the current boiler.
 func (b Boiler) GetBoilerStandardData() (BoilerStandardData, error) {
-	reqObj := requestObject{ObjectNum: 100, ObjectRequest: ReqBoilerStandardData, BoilerNum: 0, LoadNum: 0}
+	return b.GetBoilerStandardDataContext(context.Background())
+}
+
+// GetBoilerStandardDataContext returns the BoilerStandardData response for the current boiler using the provided context.
+func (b Boiler) GetBoilerStandardDataContext(ctx context.Context) (BoilerStandardData, error) {
+	reqObj := requestObject{ObjectNum: 100, ObjectRequest: ReqBoilerStandardData, BoilerNum: b.BoilerNum, LoadNum: 0}
 	var respObj = BoilerStandardData{}
-	return respObj, b.getData(reqObj, &respObj)
+	return respObj, b.getData(ctx, reqObj, &respObj)
 }
 
 // GetBoilerExtDetailData returns the BoilerExtDetailData response for the current boiler.
 func (b Boiler) GetBoilerExtDetailData() (BoilerExtDetailData, error) {
-	reqObj := requestObject{ObjectNum: 100, ObjectRequest: ReqBoilerExtDetailData, BoilerNum: 0, LoadNum: 0}
+	return b.GetBoilerExtDetailDataContext(context.Background())
+}
+
+// GetBoilerExtDetailDataContext returns the BoilerExtDetailData response for the current boiler using the provided context.
+func (b Boiler) GetBoilerExtDetailDataContext(ctx context.Context) (BoilerExtDetailData, error) {
+	reqObj := requestObject{ObjectNum: 100, ObjectRequest: ReqBoilerExtDetailData, BoilerNum: b.BoilerNum, LoadNum: 0}
 	var respObj = BoilerExtDetailData{}
-	return respObj, b.getData(reqObj, &respObj)
+	return respObj, b.getData(ctx, reqObj, &respObj)
 }
 
 // GetBoilerFactoryData returns the BoilerFactoryData response for the current boiler.
 func (b Boiler) GetBoilerFactoryData() (BoilerFactoryData, error) {
-	reqObj := requestObject{ObjectNum: 100, ObjectRequest: ReqBoilerFactoryData, BoilerNum: 0, LoadNum: 0}
+	return b.GetBoilerFactoryDataContext(context.Background())
+}
+
+// GetBoilerFactoryDataContext returns the BoilerFactoryData response for the current boiler using the provided context.
+func (b Boiler) GetBoilerFactoryDataContext(ctx context.Context) (BoilerFactoryData, error) {
+	reqObj := requestObject{ObjectNum: 100, ObjectRequest: ReqBoilerFactoryData, BoilerNum: b.BoilerNum, LoadNum: 0}
 	var respObj = BoilerFactoryData{}
-	return respObj, b.getData(reqObj, &respObj)
+	return respObj, b.getData(ctx, reqObj, &respObj)
 }
 
 // GetLoadStatusDataForLoad returns the LoadStatusData response for the current boiler and specified load.
 func (b Boiler) GetLoadStatusDataForLoad(loadNum int) (LoadStatusData, error) {
-	reqObj := requestObject{ObjectNum: 100, ObjectRequest: ReqLoadStatusData, BoilerNum: 0, LoadNum: loadNum}
+	return b.GetLoadStatusDataForLoadContext(context.Background(), loadNum)
+}
+
+// GetLoadStatusDataForLoadContext returns the LoadStatusData response for the current boiler and specified load using the provided context.
+// A *LoadMismatchError is returned if the boiler responds with data for a different load.
+func (b Boiler) GetLoadStatusDataForLoadContext(ctx context.Context, loadNum int) (LoadStatusData, error) {
+	reqObj := requestObject{ObjectNum: 100, ObjectRequest: ReqLoadStatusData, BoilerNum: b.BoilerNum, LoadNum: loadNum}
 	var respObj = LoadStatusData{}
-	return respObj, b.getData(reqObj, &respObj)
+	if err := b.getData(ctx, reqObj, &respObj); err != nil {
+		return respObj, err
+	}
+	// The boiler numbers loads from 0 in the response.
+	if respObj.Load != loadNum-1 {
+		return respObj, &LoadMismatchError{Requested: loadNum, Returned: respObj.Load + 1}
+	}
+	return respObj, nil
 }
 
 // GetLoadStatusData returns the LoadStatusData response for the active loads for the current boiler.
+// See GetLoadStatusDataContext for how partial failures are reported.
 func (b Boiler) GetLoadStatusData() ([]LoadStatusData, error) {
+	return b.GetLoadStatusDataContext(context.Background())
+}
+
+// GetLoadStatusDataContext returns the LoadStatusData response for the active loads for the current boiler using the provided context.
+// If some loads can not be read, the data for the remaining loads is returned along with a LoadErrors error
+// listing the loads that failed. Callers that can use partial results should check for LoadErrors with errors.As.
+func (b Boiler) GetLoadStatusDataContext(ctx context.Context) ([]LoadStatusData, error) {
 	var lsd = make([]LoadStatusData, 0, 4)
 
-	bsd, err := b.GetBoilerStandardData()
+	bsd, err := b.GetBoilerStandardDataContext(ctx)
 	if err != nil {
 		return lsd, err
 	}
 
+	var loadErrs LoadErrors
 	f := func(loadType, loadNum int) {
 		if loadType > 0 {
-			reqObj := requestObject{ObjectNum: 100, ObjectRequest: ReqLoadStatusData, BoilerNum: 0, LoadNum: loadNum}
-			var respObj = LoadStatusData{}
-			b.getData(reqObj, &respObj)
+			respObj, err := b.GetLoadStatusDataForLoadContext(ctx, loadNum)
+			if err != nil {
+				loadErrs = append(loadErrs, &LoadError{Load: loadNum, Err: err})
+				return
+			}
 			lsd = append(lsd, respObj)
 		}
 	}
@@ -400,6 +533,9 @@ func (b Boiler) GetLoadStatusData() ([]LoadStatusData, error) {
 	f(bsd.Load3Type, 3)
 	f(bsd.Load4Type, 4)
 
+	if len(loadErrs) > 0 {
+		return lsd, loadErrs
+	}
 	return lsd, nil
 }
 
@@ -422,6 +558,7 @@ func getLoadNumbersFromBits(in int) []int {
 }
 
 // GetErrorString returns a descripton of the error code specified. Assumes G3 Boilers
+// Only one fault is described when several are active; use DecodeFaults to get every active fault.
 func GetErrorString(minErr int, majErr int, sysErr int) string {
 	if sysErr > 0 {
 		for i := 0; i < len(systemErrorBitMask); i++ {
@@ -496,7 +633,13 @@ func GetErrorString(minErr int, majErr int, sysErr int) string {
 	return "Unknown"
 }
 
-func (b Boiler) getData(reqObj requestObject, respObj interface{}) error {
+// getData sends reqObj to the boiler and decodes the response into respObj.
+func (b Boiler) getData(ctx context.Context, reqObj interface{}, respObj interface{}) error {
+
+	read, isRead := reqObj.(requestObject)
+	if isRead && b.Capabilities != nil && !b.Capabilities.Supports(read.ObjectRequest) {
+		return &UnsupportedError{Request: read.ObjectRequest}
+	}
 
 	sep := "/"
 	if strings.HasSuffix(b.BaseURL, "/") {
@@ -505,10 +648,19 @@ func (b Boiler) getData(reqObj requestObject, respObj interface{}) error {
 
 	url := fmt.Sprintf("%s%scgi-bin/bc2-cgi", b.BaseURL, sep)
 
-	req, err := http.NewRequest("GET", url, nil)
+	if b.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, b.Timeout)
+		defer cancel()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
 		return err
 	}
+	if b.UserAgent != "" {
+		req.Header.Set("User-Agent", b.UserAgent)
+	}
 
 	jsonBytes, err := json.Marshal(reqObj)
 	if err != nil {
@@ -519,18 +671,81 @@ func (b Boiler) getData(reqObj requestObject, respObj interface{}) error {
 	q.Add("json", string(jsonBytes))
 	req.URL.RawQuery = q.Encode()
 
-	resp, err := http.DefaultClient.Do(req)
+	resp, err := b.httpClient().Do(req)
 	if err != nil {
-		return err
+		return &TransportError{URL: url, Err: err}
 	}
 	defer resp.Body.Close()
 
 	body, err := ioutil.ReadAll(resp.Body)
 	if err != nil {
-		return err
+		return &TransportError{URL: url, Err: err}
 	}
 
-	err = json.Unmarshal(body, &respObj)
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return &HTTPStatusError{StatusCode: resp.StatusCode, Status: resp.Status, Body: body}
+	}
+
+	if isRead && laterRequests[read.ObjectRequest] && isEmptyObject(body) {
+		return &UnsupportedError{Request: read.ObjectRequest}
+	}
+
+	if err = json.Unmarshal(body, &respObj); err != nil {
+		return &DecodeError{Body: body, Err: err}
+	}
 
 	return nil
 }
+
+// laterRequests lists the requests added in later firmware. Earlier firmware answers them with an empty object,
+// or one holding only the envelope keys, which getData returns as an *UnsupportedError. Other requests are decoded as usual, as an empty object can be
+// a valid response, such as an entry of an empty log.
+var laterRequests = map[int]bool{
+	ReqBoilerTempSensorData: true,
+	ReqAlertData:            true,
+	ReqBoilerVersions:       true,
+	ReqAdvancedOptionsData:  true,
+	ReqBoilerSIMData:        true,
+	ReqProgSetbackData:      true,
+}
+
+// envelopeKeys are the keys the boiler tags each object with, which are not data.
+var envelopeKeys = map[string]bool{"rbid": true, "object_no": true}
+
+// isEmptyObject returns true if body is a JSON object holding nothing but the envelope keys.
+func isEmptyObject(body []byte) bool {
+	var obj map[string]interface{}
+	if json.Unmarshal(body, &obj) != nil {
+		return false
+	}
+	for k := range envelopeKeys {
+		delete(obj, k)
+	}
+	return len(obj) == 0
+}
+
+// faultTableContext returns b.FaultTable, or the fault table for the boiler's model if it is not set.
+func (b Boiler) faultTableContext(ctx context.Context) (FaultTable, error) {
+	if b.FaultTable != nil {
+		return b.FaultTable, nil
+	}
+	bd, err := b.GetBoilerDataContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return bd.FaultTable(), nil
+}
+
+func (b Boiler) location() *time.Location {
+	if b.Location != nil {
+		return b.Location
+	}
+	return time.Local
+}
+
+func (b Boiler) httpClient() *http.Client {
+	if b.Client != nil {
+		return b.Client
+	}
+	return http.DefaultClient
+}
@@ -1,7 +1,17 @@
 package ibc
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync"
 	"testing"
+	"time"
 )
 
 var minorErrorStringMap = map[int]string{
@@ -18,3 +28,279 @@ func TestGetMinorErrorString(t *testing.T) {
 		}
 	}
 }
+
+func TestGetBoilerDataContextUsesClientOptions(t *testing.T) {
+	var gotUserAgent string
+	var gotReq requestObject
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotUserAgent = r.Header.Get("User-Agent")
+		json.Unmarshal([]byte(r.URL.Query().Get("json")), &gotReq)
+		w.Write([]byte(`{"status":3,"model":"SL 20-115 G3"}`))
+	}))
+	defer s.Close()
+
+	b := Boiler{BaseURL: s.URL, Client: s.Client(), UserAgent: "ibc-test"}
+	bd, err := b.GetBoilerDataContext(context.Background())
+	if err != nil {
+		t.Fatalf("GetBoilerDataContext returned error: %v", err)
+	}
+	if gotUserAgent != "ibc-test" {
+		t.Errorf("User-Agent is incorrect, got: %s, want: %s.", gotUserAgent, "ibc-test")
+	}
+	if gotReq.ObjectRequest != ReqBoilerData {
+		t.Errorf("object_request is incorrect, got: %d, want: %d.", gotReq.ObjectRequest, ReqBoilerData)
+	}
+	if bd.Model != "SL 20-115 G3" {
+		t.Errorf("Model is incorrect, got: %s, want: %s.", bd.Model, "SL 20-115 G3")
+	}
+}
+
+func TestGetBoilerDataTimeout(t *testing.T) {
+	done := make(chan struct{})
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-done
+	}))
+	defer s.Close()
+	defer close(done)
+
+	b := Boiler{BaseURL: s.URL, Timeout: 50 * time.Millisecond}
+	_, err := b.GetBoilerData()
+	if err == nil {
+		t.Fatal("GetBoilerData returned no error for a hung boiler.")
+	}
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("GetBoilerData error is incorrect, got: %v, want: %v.", err, context.DeadlineExceeded)
+	}
+}
+
+// newTestBoiler starts a server that answers each request object using respond, which returns
+// the HTTP status and body to send. The returned func shuts the server down.
+func newTestBoiler(respond func(req requestObject) (int, string)) (Boiler, func()) {
+	return newWriteTestBoiler(respond, nil)
+}
+
+// newWriteTestBoiler starts a server like newTestBoiler that passes each write to write, decoded from the request
+// object. Writes are answered with the HTTP status write returns and an empty object, as the boiler does.
+// If write is nil, writes are passed to respond like reads.
+func newWriteTestBoiler(respond func(req requestObject) (int, string), write func(obj map[string]interface{}) int) (Boiler, func()) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		reqJSON := []byte(r.URL.Query().Get("json"))
+		var obj map[string]interface{}
+		json.Unmarshal(reqJSON, &obj)
+		var status int
+		var body string
+		if obj["object_no"] != float64(100) && write != nil {
+			status, body = write(obj), `{}`
+		} else {
+			var req requestObject
+			json.Unmarshal(reqJSON, &req)
+			status, body = respond(req)
+		}
+		w.WriteHeader(status)
+		w.Write([]byte(body))
+	}))
+	return Boiler{BaseURL: s.URL, Client: s.Client()}, s.Close
+}
+
+// fakeBoiler holds the objects served by a boiler started with newFakeBoiler, keyed by request, load and index.
+// Each write is stored, as it was sent, as the object for its request, load and index.
+type fakeBoiler struct {
+	mu      sync.Mutex
+	objects map[string]string
+	reads   int
+	writes  int
+	// ignoreWrites causes writes to be accepted but not stored.
+	ignoreWrites bool
+	// failWrite, if set, causes the writes it returns true for to fail with a 500.
+	failWrite func(obj map[string]interface{}) bool
+	// onRead, if set, is called after each read is answered.
+	onRead func(req requestObject)
+}
+
+// writeIndexFields names the field of a written object holding its object_index.
+var writeIndexFields = map[int]string{ReqProgSetbackData: "Day"}
+
+// fakeObject is an object served by a fakeBoiler for a request, load and index.
+type fakeObject struct {
+	request int
+	load    int
+	index   int
+	body    string
+}
+
+func objectKey(request int, load int, index int) string {
+	return fmt.Sprintf("%d/%d/%d", request, load, index)
+}
+
+// newFakeBoiler starts a server that serves objects, and any set later, from the returned fakeBoiler. Requests for
+// objects it does not hold get a 404.
+func newFakeBoiler(objects ...fakeObject) (Boiler, *fakeBoiler, func()) {
+	fb := &fakeBoiler{objects: map[string]string{}}
+	fb.setAll(objects...)
+	b, done := newWriteTestBoiler(func(req requestObject) (int, string) {
+		fb.mu.Lock()
+		fb.reads++
+		body, ok := fb.objects[objectKey(req.ObjectRequest, req.LoadNum, req.ObjectIndex)]
+		onRead := fb.onRead
+		fb.mu.Unlock()
+		if onRead != nil {
+			defer onRead(req)
+		}
+		if !ok {
+			return http.StatusNotFound, ""
+		}
+		return http.StatusOK, body
+	}, func(obj map[string]interface{}) int {
+		fb.mu.Lock()
+		defer fb.mu.Unlock()
+		fb.writes++
+		if fb.failWrite != nil && fb.failWrite(obj) {
+			return http.StatusInternalServerError
+		}
+		if fb.ignoreWrites {
+			return http.StatusOK
+		}
+		request, _ := obj["object_no"].(float64)
+		load := 0
+		if l, ok := obj["Load"].(float64); ok && l >= 0 {
+			load = int(l) + 1
+		}
+		index, _ := obj[writeIndexFields[int(request)]].(float64)
+		body, _ := json.Marshal(obj)
+		fb.objects[objectKey(int(request), load, int(index))] = string(body)
+		return http.StatusOK
+	})
+	return b, fb, done
+}
+
+// set sets the object served for a request, load and index.
+func (fb *fakeBoiler) set(request int, load int, index int, body string) {
+	fb.mu.Lock()
+	defer fb.mu.Unlock()
+	fb.objects[objectKey(request, load, index)] = body
+}
+
+// setAll sets each of objects.
+func (fb *fakeBoiler) setAll(objects ...fakeObject) {
+	for _, o := range objects {
+		fb.set(o.request, o.load, o.index, o.body)
+	}
+}
+
+// remove stops serving the object for a request, load and index.
+func (fb *fakeBoiler) remove(request int, load int, index int) {
+	fb.mu.Lock()
+	defer fb.mu.Unlock()
+	delete(fb.objects, objectKey(request, load, index))
+}
+
+// get returns the object served for a request, load and index.
+func (fb *fakeBoiler) get(request int, load int, index int) string {
+	fb.mu.Lock()
+	defer fb.mu.Unlock()
+	return fb.objects[objectKey(request, load, index)]
+}
+
+// readCount returns the number of reads received.
+func (fb *fakeBoiler) readCount() int {
+	fb.mu.Lock()
+	defer fb.mu.Unlock()
+	return fb.reads
+}
+
+// writeCount returns the number of writes received.
+func (fb *fakeBoiler) writeCount() int {
+	fb.mu.Lock()
+	defer fb.mu.Unlock()
+	return fb.writes
+}
+
+func TestGetLoadStatusDataPartialFailure(t *testing.T) {
+	b, done := newTestBoiler(func(req requestObject) (int, string) {
+		switch {
+		case req.ObjectRequest == ReqBoilerStandardData:
+			return http.StatusOK, `{"Load1Type":1,"Load2Type":2,"Load3Type":2,"Load4Type":0}`
+		case req.LoadNum == 1:
+			return http.StatusOK, `{"Load":0,"Type":1,"Cycles":4}`
+		case req.LoadNum == 2:
+			return http.StatusInternalServerError, ""
+		default:
+			// Firmware answering for the wrong load.
+			return http.StatusOK, `{"Load":0,"Type":1,"Cycles":4}`
+		}
+	})
+	defer done()
+
+	lsd, err := b.GetLoadStatusData()
+	var loadErrs LoadErrors
+	if !errors.As(err, &loadErrs) {
+		t.Fatalf("GetLoadStatusData error is incorrect, got: %v, want: LoadErrors.", err)
+	}
+	if len(lsd) != 1 || lsd[0].LoadNumber() != 1 || lsd[0].Cycles != 4 {
+		t.Errorf("GetLoadStatusData partial result is incorrect, got: %+v.", lsd)
+	}
+	if loads := loadErrs.Loads(); len(loads) != 2 || loads[0] != 2 || loads[1] != 3 {
+		t.Errorf("Failed loads are incorrect, got: %v, want: [2 3].", loads)
+	}
+	var statusErr *HTTPStatusError
+	if !errors.As(loadErrs[0], &statusErr) {
+		t.Errorf("Load 2 error is incorrect, got: %v, want: *HTTPStatusError.", loadErrs[0])
+	}
+	var mismatchErr *LoadMismatchError
+	if !errors.As(loadErrs[1], &mismatchErr) || mismatchErr.Requested != 3 || mismatchErr.Returned != 1 {
+		t.Errorf("Load 3 error is incorrect, got: %v, want: *LoadMismatchError.", loadErrs[1])
+	}
+	statusErr = nil
+	if !errors.As(err, &statusErr) || statusErr.StatusCode != http.StatusInternalServerError {
+		t.Errorf("GetLoadStatusData error does not match *HTTPStatusError, got: %v.", err)
+	}
+}
+
+// newFixtureBoiler starts a server that answers each request with the testdata file mapped to its
+// object_request. Requests without a fixture get a 404.
+func newFixtureBoiler(t *testing.T, fixtures map[int]string) (Boiler, func()) {
+	bodies := make(map[int]string, len(fixtures))
+	for req, name := range fixtures {
+		body, err := ioutil.ReadFile(filepath.Join("testdata", name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		bodies[req] = string(body)
+	}
+	return newTestBoiler(func(req requestObject) (int, string) {
+		body, ok := bodies[req.ObjectRequest]
+		if !ok {
+			return http.StatusNotFound, ""
+		}
+		return http.StatusOK, body
+	})
+}
+
+func TestServicingState(t *testing.T) {
+	tests := []struct {
+		servicing                    int
+		state                        ServicingState
+		servicingLoads, callingLoads int
+	}{
+		{0x0201, ServicingNormal, 1, 1},
+		{0x0000, ServicingNormal, 0, 0},
+		{0xFFFF, ServicingRemote, 0, 0},
+		{0xF000, ServicingSummerOff, 0, 0},
+		{0xF010, ServicingSummerOff, 0, 0},
+	}
+	for _, tt := range tests {
+		bedd := BoilerExtDetailData{Servicing: tt.servicing}
+		if bedd.ServicingState() != tt.state {
+			t.Errorf("ServicingState(0x%04X) is incorrect, got: %v, want: %v.", tt.servicing, bedd.ServicingState(), tt.state)
+		}
+		if n := len(bedd.ServicingLoadNumbers()); n != tt.servicingLoads {
+			t.Errorf("ServicingLoadNumbers(0x%04X) is incorrect, got: %v.", tt.servicing, bedd.ServicingLoadNumbers())
+		}
+		if n := len(bedd.CallingLoadNumbers()); n != tt.callingLoads {
+			t.Errorf("CallingLoadNumbers(0x%04X) is incorrect, got: %v.", tt.servicing, bedd.CallingLoadNumbers())
+		}
+		if n := len(bedd.CirculatingLoadNumbers()); n != 0 && tt.state != ServicingNormal {
+			t.Errorf("CirculatingLoadNumbers(0x%04X) is incorrect, got: %v.", tt.servicing, bedd.CirculatingLoadNumbers())
+		}
+	}
+}
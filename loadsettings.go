@@ -0,0 +1,246 @@
+package ibc
+
+import (
+	"context"
+	"fmt"
+)
+
+// LoadSettingsData represents the data returned by the ReqBoilerLoadSettingsData request.
+type LoadSettingsData struct {
+	//"rbid": 0
+	//"object_no": 16
+	Load     int `json:"Load"`
+	Type     int `json:"Type"`
+	Priority int `json:"Priority"`
+	// SetPoint is the target supply temperature for Set Point and DHW loads.
+	SetPoint          Temperature `json:"SetPoint"`
+	DesignSupplyTemp  Temperature `json:"DesignSupplyT"`
+	DesignOutdoorTemp Temperature `json:"DesignOutdoorT"`
+	DesignIndoorTemp  Temperature `json:"DesignIndoorT"`
+	MinSupplyTemp     Temperature `json:"MinSupplyT"`
+	MaxSupplyTemp     Temperature `json:"MaxSupplyT"`
+	// Differential is how far the supply temperature may exceed the target before the burner stops.
+	Differential TemperatureDelta `json:"Differential"`
+	// WarmWeatherShutdown is the outdoor temperature above which the load does not call for heat.
+	WarmWeatherShutdown Temperature `json:"WWSD"`
+}
+
+// LoadNumber returns the 1 based load number for these settings. The boiler reports Load starting at 0.
+func (lsd LoadSettingsData) LoadNumber() int {
+	return lsd.Load + 1
+}
+
+// LoadTypeName returns the name of the LoadType for this Load.
+func (lsd LoadSettingsData) LoadTypeName() string {
+	return loadName(lsd.Type)
+}
+
+// Load setting limits, in quarter degrees Celsius.
+const (
+	minSupplyTemp   = Temperature(10 * 4)  // 50°F
+	maxSupplyTemp   = Temperature(88 * 4)  // 190°F
+	minOutdoorTemp  = Temperature(-40 * 4) // -40°F
+	maxOutdoorTemp  = Temperature(20 * 4)  // 68°F
+	minIndoorTemp   = Temperature(10 * 4)  // 50°F
+	maxIndoorTemp   = Temperature(30 * 4)  // 86°F
+	minDifferential = TemperatureDelta(1 * 4)
+	maxDifferential = TemperatureDelta(20 * 4)
+	minPriority     = 1
+	maxPriority     = 4
+)
+
+// Load types, as listed in loadNames.
+const (
+	loadTypeOff      = 0
+	loadTypeDHW      = 1
+	loadTypeReset    = 2
+	loadTypeSetPoint = 3
+)
+
+// loadField is a setting checked by Validate.
+type loadField struct {
+	name     string
+	value    interface{}
+	min, max interface{}
+	inRange  bool
+	used     bool
+	zero     bool
+}
+
+// fields returns the settings checked by Validate, and whether the load's Type uses each.
+func (lsd LoadSettingsData) fields() []loadField {
+	setPoint := lsd.Type == loadTypeDHW || lsd.Type == loadTypeSetPoint
+	reset := lsd.Type == loadTypeReset
+	temp := func(name string, value Temperature, min Temperature, max Temperature, used bool) loadField {
+		return loadField{name, value, min, max, value >= min && value <= max, used, value == 0}
+	}
+	return []loadField{
+		temp("SetPoint", lsd.SetPoint, minSupplyTemp, maxSupplyTemp, setPoint),
+		temp("DesignSupplyTemp", lsd.DesignSupplyTemp, minSupplyTemp, maxSupplyTemp, reset),
+		temp("MinSupplyTemp", lsd.MinSupplyTemp, minSupplyTemp, maxSupplyTemp, reset),
+		temp("MaxSupplyTemp", lsd.MaxSupplyTemp, lsd.MinSupplyTemp, maxSupplyTemp, reset),
+		temp("DesignOutdoorTemp", lsd.DesignOutdoorTemp, minOutdoorTemp, maxOutdoorTemp, reset),
+		temp("DesignIndoorTemp", lsd.DesignIndoorTemp, minIndoorTemp, maxIndoorTemp, reset),
+		temp("WarmWeatherShutdown", lsd.WarmWeatherShutdown, minIndoorTemp, maxIndoorTemp, reset),
+		{"Differential", lsd.Differential, minDifferential, maxDifferential,
+			lsd.Differential >= minDifferential && lsd.Differential <= maxDifferential, setPoint || reset, lsd.Differential == 0},
+		{"Priority", lsd.Priority, minPriority, maxPriority,
+			lsd.Priority >= minPriority && lsd.Priority <= maxPriority, lsd.Type != loadTypeOff, lsd.Priority == 0},
+	}
+}
+
+// Uses returns true if the load's Type uses the named setting, ex "SetPoint" for DHW and Set Point loads. The names
+// are those reported in RangeError.Field.
+func (lsd LoadSettingsData) Uses(field string) bool {
+	for _, f := range lsd.fields() {
+		if f.name == field {
+			return f.used
+		}
+	}
+	return false
+}
+
+// Validate returns a *RangeError if any of the settings are outside the range the boiler accepts. The boiler reports
+// the settings the load's Type does not use as zero, so those are only checked when they are set. For example, the
+// outdoor reset settings of a DHW load may be zero.
+func (lsd LoadSettingsData) Validate() error {
+	for _, f := range lsd.fields() {
+		if !f.inRange && (f.used || !f.zero) {
+			return &RangeError{Field: f.name, Value: f.value, Min: f.min, Max: f.max}
+		}
+	}
+	return nil
+}
+
+// matches returns true if got has the same load, type and used settings as lsd. The boiler may zero the settings
+// the load's Type does not use.
+func (lsd LoadSettingsData) matches(got LoadSettingsData) bool {
+	if got.Load != lsd.Load || got.Type != lsd.Type {
+		return false
+	}
+	want, read := lsd.fields(), got.fields()
+	for i := range want {
+		if want[i].used && want[i].value != read[i].value {
+			return false
+		}
+	}
+	return true
+}
+
+func checkLoadNum(loadNum int) error {
+	if loadNum < 1 || loadNum > 4 {
+		return fmt.Errorf("ibc: invalid load number %d", loadNum)
+	}
+	return nil
+}
+
+// GetLoadSettingsData returns the LoadSettingsData response for the current boiler and specified load.
+func (b Boiler) GetLoadSettingsData(loadNum int) (LoadSettingsData, error) {
+	return b.GetLoadSettingsDataContext(context.Background(), loadNum)
+}
+
+// GetLoadSettingsDataContext returns the LoadSettingsData response for the current boiler and specified load using the provided context.
+// A *LoadMismatchError is returned if the boiler responds with data for a different load.
+func (b Boiler) GetLoadSettingsDataContext(ctx context.Context, loadNum int) (LoadSettingsData, error) {
+	reqObj := requestObject{ObjectNum: 100, ObjectRequest: ReqBoilerLoadSettingsData, BoilerNum: b.BoilerNum, LoadNum: loadNum}
+	var respObj = LoadSettingsData{}
+	if err := b.getData(ctx, reqObj, &respObj); err != nil {
+		return respObj, err
+	}
+	if respObj.Load != loadNum-1 {
+		return respObj, &LoadMismatchError{Requested: loadNum, Returned: respObj.Load + 1}
+	}
+	return respObj, nil
+}
+
+// SetLoadSettings writes the settings for the load in lsd. The settings are validated before they are written
+// and read back afterwards, and a *VerifyError is returned if the settings used by the load's Type do not match.
+// Writes must be allowed on the Boiler.
+func (b Boiler) SetLoadSettings(lsd LoadSettingsData) (WriteResult, error) {
+	return b.SetLoadSettingsContext(context.Background(), lsd)
+}
+
+// SetLoadSettingsContext writes the settings for the load in lsd using the provided context. See SetLoadSettings.
+func (b Boiler) SetLoadSettingsContext(ctx context.Context, lsd LoadSettingsData) (WriteResult, error) {
+	if err := checkLoadNum(lsd.LoadNumber()); err != nil {
+		return WriteResult{Request: ReqBoilerLoadSettingsData}, err
+	}
+	if err := lsd.Validate(); err != nil {
+		return WriteResult{Request: ReqBoilerLoadSettingsData}, err
+	}
+
+	res, err := b.writeData(ctx, ReqBoilerLoadSettingsData, lsd)
+	if err != nil || !res.Sent {
+		return res, err
+	}
+
+	got, err := b.GetLoadSettingsDataContext(ctx, lsd.LoadNumber())
+	if err != nil {
+		return res, err
+	}
+	if !lsd.matches(got) {
+		return res, &VerifyError{Request: ReqBoilerLoadSettingsData, Written: lsd, Read: got}
+	}
+	res.Verified = true
+	return res, nil
+}
+
+// updateLoadSettings reads the settings for a load, applies update and writes them back. An error is returned if
+// the load's Type does not use each of fields.
+func (b Boiler) updateLoadSettings(ctx context.Context, loadNum int, fields []string, update func(*LoadSettingsData)) (WriteResult, error) {
+	if err := checkLoadNum(loadNum); err != nil {
+		return WriteResult{Request: ReqBoilerLoadSettingsData}, err
+	}
+	lsd, err := b.GetLoadSettingsDataContext(ctx, loadNum)
+	if err != nil {
+		return WriteResult{Request: ReqBoilerLoadSettingsData}, err
+	}
+	for _, field := range fields {
+		if !lsd.Uses(field) {
+			return WriteResult{Request: ReqBoilerLoadSettingsData},
+				fmt.Errorf("ibc: load %d is a %s load, which does not use %s", loadNum, lsd.LoadTypeName(), field)
+		}
+	}
+	update(&lsd)
+	return b.SetLoadSettingsContext(ctx, lsd)
+}
+
+// SetLoadSetPoint sets the target supply temperature for a Set Point or DHW load. An error is returned for other loads.
+func (b Boiler) SetLoadSetPoint(loadNum int, setPoint Temperature) (WriteResult, error) {
+	return b.SetLoadSetPointContext(context.Background(), loadNum, setPoint)
+}
+
+// SetLoadSetPointContext sets the target supply temperature for a Set Point or DHW load using the provided context.
+func (b Boiler) SetLoadSetPointContext(ctx context.Context, loadNum int, setPoint Temperature) (WriteResult, error) {
+	return b.updateLoadSettings(ctx, loadNum, []string{"SetPoint"}, func(lsd *LoadSettingsData) {
+		lsd.SetPoint = setPoint
+	})
+}
+
+// SetLoadPriority sets the priority of a load, from 1 (highest) to 4. An error is returned if the load is off.
+func (b Boiler) SetLoadPriority(loadNum int, priority int) (WriteResult, error) {
+	return b.SetLoadPriorityContext(context.Background(), loadNum, priority)
+}
+
+// SetLoadPriorityContext sets the priority of a load, from 1 (highest) to 4, using the provided context.
+func (b Boiler) SetLoadPriorityContext(ctx context.Context, loadNum int, priority int) (WriteResult, error) {
+	return b.updateLoadSettings(ctx, loadNum, []string{"Priority"}, func(lsd *LoadSettingsData) {
+		lsd.Priority = priority
+	})
+}
+
+// SetLoadDesignTemps sets the design supply, outdoor and indoor temperatures of a Reset Heating load. An error is
+// returned for other loads.
+func (b Boiler) SetLoadDesignTemps(loadNum int, supply Temperature, outdoor Temperature, indoor Temperature) (WriteResult, error) {
+	return b.SetLoadDesignTempsContext(context.Background(), loadNum, supply, outdoor, indoor)
+}
+
+// SetLoadDesignTempsContext sets the design supply, outdoor and indoor temperatures of a Reset Heating load using the provided context.
+func (b Boiler) SetLoadDesignTempsContext(ctx context.Context, loadNum int, supply Temperature, outdoor Temperature, indoor Temperature) (WriteResult, error) {
+	fields := []string{"DesignSupplyTemp", "DesignOutdoorTemp", "DesignIndoorTemp"}
+	return b.updateLoadSettings(ctx, loadNum, fields, func(lsd *LoadSettingsData) {
+		lsd.DesignSupplyTemp = supply
+		lsd.DesignOutdoorTemp = outdoor
+		lsd.DesignIndoorTemp = indoor
+	})
+}
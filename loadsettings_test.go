@@ -0,0 +1,160 @@
+package ibc
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+// testLoadSettings are the settings of load 2, a Set Point load.
+var testLoadSettings = fakeObject{ReqBoilerLoadSettingsData, 2, 0, `{"rbid":0,"object_no":16,"Load":1,"Type":3,"Priority":2,"SetPoint":320,"DesignSupplyT":288,"DesignOutdoorT":-80,` +
+	`"DesignIndoorT":84,"MinSupplyT":100,"MaxSupplyT":340,"Differential":20,"WWSD":72}`}
+
+func TestSetLoadSetPoint(t *testing.T) {
+	b, fb, done := newFakeBoiler(testLoadSettings)
+	defer done()
+	b.AllowWrites = true
+
+	res, err := b.SetLoadSetPoint(2, TemperatureFromF(180))
+	if err != nil {
+		t.Fatalf("SetLoadSetPoint returned error: %v", err)
+	}
+	if !res.Sent || !res.Verified || fb.writeCount() != 1 {
+		t.Errorf("SetLoadSetPoint result is incorrect, got: %+v after %d writes, want: sent and verified.", res, fb.writeCount())
+	}
+	lsd, err := b.GetLoadSettingsData(2)
+	if err != nil || lsd.SetPoint != TemperatureFromF(180) || lsd.Priority != 2 {
+		t.Errorf("Load settings after write are incorrect, got: %+v %v.", lsd, err)
+	}
+}
+
+func TestSetLoadSetPointDHW(t *testing.T) {
+	// DHW loads do not use the outdoor reset settings, which the boiler reports as zero.
+	dhw := fakeObject{ReqBoilerLoadSettingsData, 1, 0, `{"rbid":0,"object_no":16,"Load":0,"Type":1,"Priority":1,"SetPoint":240,"DesignSupplyT":0,"DesignOutdoorT":0,` +
+		`"DesignIndoorT":0,"MinSupplyT":0,"MaxSupplyT":0,"Differential":20,"WWSD":0}`}
+	b, fb, done := newFakeBoiler(dhw)
+	defer done()
+	b.AllowWrites = true
+
+	res, err := b.SetLoadSetPoint(1, TemperatureFromF(140))
+	if err != nil || !res.Verified || fb.writeCount() != 1 {
+		t.Fatalf("SetLoadSetPoint on a DHW load is incorrect, got: %+v %v after %d writes, want: verified.", res, err, fb.writeCount())
+	}
+	if lsd, err := b.GetLoadSettingsData(1); err != nil || lsd.SetPoint != TemperatureFromF(140) {
+		t.Errorf("Load settings after write are incorrect, got: %+v %v.", lsd, err)
+	}
+}
+
+func TestSetLoadSettingsLoadNumber(t *testing.T) {
+	b, fb, done := newFakeBoiler(testLoadSettings)
+	defer done()
+	b.AllowWrites = true
+
+	lsd, err := b.GetLoadSettingsData(2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lsd.Load = 4
+	if _, err := b.SetLoadSettings(lsd); err == nil || fb.writeCount() != 0 {
+		t.Errorf("SetLoadSettings for load 5 is incorrect, got: %v after %d writes, want: an error.", err, fb.writeCount())
+	}
+}
+
+func TestSetLoadSettingsRequiresOptIn(t *testing.T) {
+	b, fb, done := newFakeBoiler(testLoadSettings)
+	defer done()
+
+	_, err := b.SetLoadPriority(2, 1)
+	if !errors.Is(err, ErrWritesDisabled) || fb.writeCount() != 0 {
+		t.Errorf("SetLoadPriority without AllowWrites is incorrect, got: %v after %d writes, want: %v.", err, fb.writeCount(), ErrWritesDisabled)
+	}
+}
+
+func TestSetLoadSettingsDryRun(t *testing.T) {
+	b, fb, done := newFakeBoiler(testLoadSettings)
+	defer done()
+	b.DryRun = true
+
+	res, err := b.SetLoadPriority(2, 1)
+	if err != nil {
+		t.Fatalf("SetLoadPriority returned error: %v", err)
+	}
+	if res.Sent || fb.writeCount() != 0 {
+		t.Errorf("Dry run sent the request.")
+	}
+	if !strings.Contains(res.Object, `"Priority":1`) || !strings.Contains(res.Object, `"object_no":16`) {
+		t.Errorf("Dry run request is incorrect, got: %s.", res.Object)
+	}
+}
+
+func TestSetLoadSettingsRange(t *testing.T) {
+	b, fb, done := newFakeBoiler(testLoadSettings)
+	defer done()
+	b.AllowWrites = true
+
+	_, err := b.SetLoadSetPoint(2, TemperatureFromF(210))
+	var rangeErr *RangeError
+	if !errors.As(err, &rangeErr) || rangeErr.Field != "SetPoint" || fb.writeCount() != 0 {
+		t.Errorf("SetLoadSetPoint out of range is incorrect, got: %v after %d writes, want: *RangeError.", err, fb.writeCount())
+	}
+
+	_, err = b.SetLoadPriority(2, 5)
+	if !errors.As(err, &rangeErr) || rangeErr.Field != "Priority" {
+		t.Errorf("SetLoadPriority out of range is incorrect, got: %v, want: *RangeError.", err)
+	}
+}
+
+func TestSetLoadSettingsNotApplied(t *testing.T) {
+	b, fb, done := newFakeBoiler(testLoadSettings)
+	defer done()
+	fb.ignoreWrites = true
+	b.AllowWrites = true
+
+	res, err := b.SetLoadSetPoint(2, TemperatureFromF(160))
+	var verifyErr *VerifyError
+	if !errors.As(err, &verifyErr) || !res.Sent || res.Verified {
+		t.Errorf("SetLoadSetPoint is incorrect, got: %+v %v, want: *VerifyError.", res, err)
+	}
+}
+
+func TestSetLoadSettingsUnusedField(t *testing.T) {
+	b, fb, done := newFakeBoiler(testLoadSettings)
+	defer done()
+	b.AllowWrites = true
+
+	// Set Point loads do not use the design temperatures.
+	_, err := b.SetLoadDesignTemps(2, TemperatureFromF(160), TemperatureFromF(0), TemperatureFromF(68))
+	if err == nil || fb.writeCount() != 0 {
+		t.Errorf("SetLoadDesignTemps on a Set Point load is incorrect, got: %v after %d writes, want: an error.", err, fb.writeCount())
+	}
+
+	// Settings the load does not use may be zero, but are checked when set.
+	lsd, err := b.GetLoadSettingsData(2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lsd.Type = loadTypeDHW
+	if err := lsd.Validate(); err != nil {
+		t.Errorf("Validate of a DHW load with reset settings is incorrect, got: %v, want: no error.", err)
+	}
+	lsd.DesignSupplyTemp = TemperatureFromC(1000)
+	var rangeErr *RangeError
+	if err := lsd.Validate(); !errors.As(err, &rangeErr) || rangeErr.Field != "DesignSupplyTemp" {
+		t.Errorf("Validate of an unused setting out of range is incorrect, got: %v, want: *RangeError.", err)
+	}
+}
+
+func TestLoadSettingsMatches(t *testing.T) {
+	// The boiler may zero the settings a DHW load does not use.
+	written := LoadSettingsData{Load: 0, Type: loadTypeDHW, Priority: 1, SetPoint: TemperatureFromF(140), Differential: 20,
+		DesignSupplyTemp: TemperatureFromF(160)}
+	read := written
+	read.DesignSupplyTemp = 0
+	if !written.matches(read) {
+		t.Errorf("matches with an unused setting zeroed is incorrect, got: false, want: true.")
+	}
+	read.SetPoint = TemperatureFromF(120)
+	if written.matches(read) {
+		t.Errorf("matches with a different SetPoint is incorrect, got: true, want: false.")
+	}
+}
@@ -0,0 +1,149 @@
+package ibc
+
+import (
+	"context"
+	"fmt"
+)
+
+// Sensor identifies a temperature sensor input on the boiler.
+type Sensor int
+
+// Sensor Constants
+const (
+	SensorSupply  Sensor = 0
+	SensorReturn  Sensor = 1
+	SensorOutdoor Sensor = 2
+	SensorAux     Sensor = 3
+	SensorDHW     Sensor = 4
+	SensorStack   Sensor = 5
+)
+
+var sensorNames = [...]string{"Supply", "Return", "Outdoor", "Sec/Indoor", "DHW Tank", "Stack"}
+
+func (s Sensor) String() string {
+	if s < 0 || int(s) >= len(sensorNames) {
+		return fmt.Sprintf("Unknown (%d)", int(s))
+	}
+	return sensorNames[s]
+}
+
+// SensorState is the condition of a temperature sensor, determined from its raw reading.
+type SensorState int
+
+// Sensor State Constants
+const (
+	SensorOK           SensorState = 0
+	SensorOpen         SensorState = 1
+	SensorShorted      SensorState = 2
+	SensorNotInstalled SensorState = 3
+)
+
+var sensorStateNames = [...]string{"OK", "Open", "Shorted", "Not Installed"}
+
+func (s SensorState) String() string {
+	if s < 0 || int(s) >= len(sensorStateNames) {
+		return fmt.Sprintf("Unknown (%d)", int(s))
+	}
+	return sensorStateNames[s]
+}
+
+// The sensors are thermistors read by a 10 bit ADC. An open circuit reads near full scale, a short near zero.
+const (
+	sensorOpenRaw    = 1000
+	sensorShortedRaw = 20
+)
+
+// BoilerTempSensorData represents the data returned by the ReqBoilerTempSensorData request.
+type BoilerTempSensorData struct {
+	//"rbid": 0
+	//"object_no": 27
+	// Installed has a bit set, 1 << Sensor, for each sensor the boiler is configured to use.
+	Installed   int         `json:"Installed"`
+	SupplyTemp  Temperature `json:"SupplyT"`
+	SupplyRaw   int         `json:"SupplyRaw"`
+	ReturnTemp  Temperature `json:"ReturnT"`
+	ReturnRaw   int         `json:"ReturnRaw"`
+	OutdoorTemp Temperature `json:"OutdoorT"`
+	OutdoorRaw  int         `json:"OutdoorRaw"`
+	AuxTemp     Temperature `json:"AuxT"`
+	AuxRaw      int         `json:"AuxRaw"`
+	DHWTemp     Temperature `json:"DHWT"`
+	DHWRaw      int         `json:"DHWRaw"`
+	StackTemp   Temperature `json:"StackT"`
+	StackRaw    int         `json:"StackRaw"`
+}
+
+// TempSensor is the reading and condition of a single temperature sensor.
+type TempSensor struct {
+	Sensor    Sensor
+	Installed bool
+	// Raw is the ADC reading of the sensor input.
+	Raw   int
+	Temp  Temperature
+	State SensorState
+}
+
+// Fault returns true if the sensor is installed and reads open or shorted.
+func (ts TempSensor) Fault() bool {
+	return ts.State == SensorOpen || ts.State == SensorShorted
+}
+
+// Format returns the sensor reading in the specified units, or its state if it is not OK.
+func (ts TempSensor) Format(u Units) string {
+	if ts.State != SensorOK {
+		return ts.State.String()
+	}
+	return ts.Temp.Format(u)
+}
+
+// Sensors returns the reading and condition of each temperature sensor.
+func (d BoilerTempSensorData) Sensors() []TempSensor {
+	readings := []struct {
+		temp Temperature
+		raw  int
+	}{
+		{d.SupplyTemp, d.SupplyRaw},
+		{d.ReturnTemp, d.ReturnRaw},
+		{d.OutdoorTemp, d.OutdoorRaw},
+		{d.AuxTemp, d.AuxRaw},
+		{d.DHWTemp, d.DHWRaw},
+		{d.StackTemp, d.StackRaw},
+	}
+	sensors := make([]TempSensor, len(readings))
+	for i, r := range readings {
+		ts := TempSensor{Sensor: Sensor(i), Installed: d.Installed&(1<<uint(i)) != 0, Raw: r.raw, Temp: r.temp}
+		switch {
+		case !ts.Installed:
+			ts.State = SensorNotInstalled
+		case r.raw >= sensorOpenRaw:
+			ts.State = SensorOpen
+		case r.raw <= sensorShortedRaw:
+			ts.State = SensorShorted
+		}
+		sensors[i] = ts
+	}
+	return sensors
+}
+
+// FaultySensors returns the installed sensors that read open or shorted.
+func (d BoilerTempSensorData) FaultySensors() []TempSensor {
+	var faulty []TempSensor
+	for _, ts := range d.Sensors() {
+		if ts.Fault() {
+			faulty = append(faulty, ts)
+		}
+	}
+	return faulty
+}
+
+// GetBoilerTempSensorData returns the BoilerTempSensorData for the current boiler.
+func (b Boiler) GetBoilerTempSensorData() (BoilerTempSensorData, error) {
+	return b.GetBoilerTempSensorDataContext(context.Background())
+}
+
+// GetBoilerTempSensorDataContext returns the BoilerTempSensorData for the current boiler using the provided context.
+func (b Boiler) GetBoilerTempSensorDataContext(ctx context.Context) (BoilerTempSensorData, error) {
+	reqObj := requestObject{ObjectNum: 100, ObjectRequest: ReqBoilerTempSensorData, BoilerNum: b.BoilerNum}
+	var respObj = BoilerTempSensorData{}
+	return respObj, b.getData(ctx, reqObj, &respObj)
+}
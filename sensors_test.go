@@ -0,0 +1,36 @@
+package ibc
+
+import "testing"
+
+func TestBoilerTempSensorDataGolden(t *testing.T) {
+	var tsd BoilerTempSensorData
+	testGolden(t, "temp_sensor_data", &tsd)
+}
+
+func TestTempSensors(t *testing.T) {
+	b, done := newFixtureBoiler(t, map[int]string{ReqBoilerTempSensorData: "temp_sensor_data.json"})
+	defer done()
+
+	tsd, err := b.GetBoilerTempSensorData()
+	if err != nil {
+		t.Fatalf("GetBoilerTempSensorData returned error: %v", err)
+	}
+	want := []SensorState{SensorOK, SensorOK, SensorOpen, SensorShorted, SensorNotInstalled, SensorNotInstalled}
+	sensors := tsd.Sensors()
+	if len(sensors) != len(want) {
+		t.Fatalf("Sensors length is incorrect, got: %d, want: %d.", len(sensors), len(want))
+	}
+	for i, ts := range sensors {
+		if ts.Sensor != Sensor(i) || ts.State != want[i] {
+			t.Errorf("%v sensor state is incorrect, got: %v, want: %v.", ts.Sensor, ts.State, want[i])
+		}
+	}
+	if got := sensors[SensorSupply].Format(Imperial); got != "162°F" {
+		t.Errorf("Supply sensor Format is incorrect, got: %v, want: %v.", got, "162°F")
+	}
+
+	faulty := tsd.FaultySensors()
+	if len(faulty) != 2 || faulty[0].Sensor != SensorOutdoor || faulty[1].Sensor != SensorAux {
+		t.Errorf("FaultySensors is incorrect, got: %+v.", faulty)
+	}
+}
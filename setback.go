@@ -0,0 +1,445 @@
+package ibc
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+)
+
+// maxSetbackWindows is the number of setback windows the boiler stores for each day.
+const maxSetbackWindows = 4
+
+// SetbackData represents the data returned by the ReqBoilerSetbackData request for a load.
+type SetbackData struct {
+	//"rbid": 0
+	//"object_no": 14
+	Load    int  `json:"Load"`
+	Enabled bool `json:"Enabled"`
+	// SetbackTemp is the default target temperature used while the load is set back.
+	SetbackTemp Temperature `json:"SetbackT"`
+}
+
+// ProgSetbackData represents the data returned by the ReqProgSetbackData request for a load and day of the week.
+type ProgSetbackData struct {
+	//"rbid": 0
+	//"object_no": 50
+	Load   int                `json:"Load"`
+	Day    int                `json:"Day"`
+	Events []ProgSetbackEvent `json:"Events"`
+}
+
+// ProgSetbackEvent is a single period of setback in the ProgSetbackData response.
+type ProgSetbackEvent struct {
+	// Start and End are in minutes after midnight.
+	Start       int         `json:"Start"`
+	End         int         `json:"End"`
+	SetbackTemp Temperature `json:"SetbackT"`
+}
+
+// Format returns the event as a window in the specified units, ex "22:00-24:00 60°F".
+func (e ProgSetbackEvent) Format(u Units) string {
+	return fmt.Sprintf("%s-%s %s", TimeOfDay(e.Start), TimeOfDay(e.End), e.SetbackTemp.Format(u))
+}
+
+// TimeOfDay is a time of day, in minutes after midnight.
+type TimeOfDay int
+
+// ParseTimeOfDay parses a time of day in 24 hour HH:MM format. "24:00" is accepted as the end of the day.
+func ParseTimeOfDay(s string) (TimeOfDay, error) {
+	var h, m int
+	if n, err := fmt.Sscanf(strings.TrimSpace(s), "%d:%d", &h, &m); err != nil || n != 2 || h < 0 || m < 0 || m > 59 || h*60+m > 24*60 {
+		return 0, fmt.Errorf("ibc: invalid time of day %q, must be HH:MM", s)
+	}
+	return TimeOfDay(h*60 + m), nil
+}
+
+func (t TimeOfDay) String() string {
+	return fmt.Sprintf("%02d:%02d", int(t)/60, int(t)%60)
+}
+
+// SetbackWindow is a period of a day during which a load is set back to a lower temperature.
+type SetbackWindow struct {
+	Start       TimeOfDay
+	End         TimeOfDay
+	Temperature Temperature
+}
+
+// SetbackSchedule is the weekly setback schedule for a load.
+type SetbackSchedule struct {
+	// Load is the 1 based load number.
+	Load    int
+	Enabled bool
+	// Temperature is the default setback temperature.
+	Temperature Temperature
+	// Days holds the setback windows for each day, indexed by time.Weekday.
+	Days [7][]SetbackWindow
+}
+
+// Validate returns an error if a window is empty, overlaps another, or has a temperature outside the range the
+// boiler accepts, or if a day has more windows than the boiler can store.
+func (s SetbackSchedule) Validate() error {
+	if s.Load < 1 || s.Load > 4 {
+		return fmt.Errorf("ibc: invalid load number %d", s.Load)
+	}
+	if s.Temperature < minSupplyTemp || s.Temperature > maxSupplyTemp {
+		return &RangeError{Field: "Temperature", Value: s.Temperature, Min: minSupplyTemp, Max: maxSupplyTemp}
+	}
+	for day, windows := range s.Days {
+		if len(windows) > maxSetbackWindows {
+			return fmt.Errorf("ibc: %s has %d setback windows, the boiler stores at most %d", time.Weekday(day), len(windows), maxSetbackWindows)
+		}
+		sorted := sortedWindows(windows)
+		for i, w := range sorted {
+			if w.Start < 0 || w.End > 24*60 || w.Start >= w.End {
+				return fmt.Errorf("ibc: invalid %s setback window %s-%s", time.Weekday(day), w.Start, w.End)
+			}
+			if i > 0 && w.Start < sorted[i-1].End {
+				return fmt.Errorf("ibc: %s setback windows %s-%s and %s-%s overlap", time.Weekday(day), sorted[i-1].Start, sorted[i-1].End, w.Start, w.End)
+			}
+			if w.Temperature < minSupplyTemp || w.Temperature > maxSupplyTemp {
+				return &RangeError{Field: time.Weekday(day).String() + " Temperature", Value: w.Temperature, Min: minSupplyTemp, Max: maxSupplyTemp}
+			}
+		}
+	}
+	return nil
+}
+
+func sortedWindows(windows []SetbackWindow) []SetbackWindow {
+	sorted := append([]SetbackWindow(nil), windows...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Start < sorted[j].Start })
+	return sorted
+}
+
+// Format renders the schedule as text, one line per day, with temperatures in the specified units.
+func (s SetbackSchedule) Format(u Units) string {
+	var sb strings.Builder
+	enabled := "Disabled"
+	if s.Enabled {
+		enabled = "Enabled"
+	}
+	fmt.Fprintf(&sb, "Load %d Setback: %s, %s\n", s.Load, enabled, s.Temperature.Format(u))
+	for day, windows := range s.Days {
+		fmt.Fprintf(&sb, "%-11s", time.Weekday(day).String()+":")
+		if len(windows) == 0 {
+			sb.WriteString("None")
+		}
+		for i, w := range sortedWindows(windows) {
+			if i > 0 {
+				sb.WriteString(", ")
+			}
+			fmt.Fprintf(&sb, "%s-%s %s", w.Start, w.End, w.Temperature.Format(u))
+		}
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+func (s SetbackSchedule) String() string {
+	return s.Format(Metric)
+}
+
+// GetSetbackData returns the SetbackData response for the current boiler and specified load.
+func (b Boiler) GetSetbackData(loadNum int) (SetbackData, error) {
+	return b.GetSetbackDataContext(context.Background(), loadNum)
+}
+
+// GetSetbackDataContext returns the SetbackData response for the current boiler and specified load using the provided context.
+// A *LoadMismatchError is returned if the boiler responds with data for a different load.
+func (b Boiler) GetSetbackDataContext(ctx context.Context, loadNum int) (SetbackData, error) {
+	reqObj := requestObject{ObjectNum: 100, ObjectRequest: ReqBoilerSetbackData, BoilerNum: b.BoilerNum, LoadNum: loadNum}
+	var respObj = SetbackData{}
+	if err := b.getData(ctx, reqObj, &respObj); err != nil {
+		return respObj, err
+	}
+	if respObj.Load != loadNum-1 {
+		return respObj, &LoadMismatchError{Requested: loadNum, Returned: respObj.Load + 1}
+	}
+	return respObj, nil
+}
+
+// GetProgSetbackData returns the ProgSetbackData response for the current boiler, specified load and day of the week.
+func (b Boiler) GetProgSetbackData(loadNum int, day time.Weekday) (ProgSetbackData, error) {
+	return b.GetProgSetbackDataContext(context.Background(), loadNum, day)
+}
+
+// GetProgSetbackDataContext returns the ProgSetbackData response for the current boiler, specified load and day of the week using the provided context.
+// A *LoadMismatchError is returned if the boiler responds with data for a different load.
+func (b Boiler) GetProgSetbackDataContext(ctx context.Context, loadNum int, day time.Weekday) (ProgSetbackData, error) {
+	reqObj := requestObject{ObjectNum: 100, ObjectRequest: ReqProgSetbackData, BoilerNum: b.BoilerNum, LoadNum: loadNum, ObjectIndex: int(day)}
+	var respObj = ProgSetbackData{}
+	if err := b.getData(ctx, reqObj, &respObj); err != nil {
+		return respObj, err
+	}
+	if respObj.Load != loadNum-1 {
+		return respObj, &LoadMismatchError{Requested: loadNum, Returned: respObj.Load + 1}
+	}
+	if respObj.Day != int(day) {
+		return respObj, fmt.Errorf("ibc: requested setback schedule for %s, boiler returned day %d", day, respObj.Day)
+	}
+	return respObj, nil
+}
+
+// GetSetbackSchedule returns the weekly setback schedule for the specified load.
+func (b Boiler) GetSetbackSchedule(loadNum int) (SetbackSchedule, error) {
+	return b.GetSetbackScheduleContext(context.Background(), loadNum)
+}
+
+// GetSetbackScheduleContext returns the weekly setback schedule for the specified load using the provided context.
+func (b Boiler) GetSetbackScheduleContext(ctx context.Context, loadNum int) (SetbackSchedule, error) {
+	s := SetbackSchedule{Load: loadNum}
+	sd, err := b.GetSetbackDataContext(ctx, loadNum)
+	if err != nil {
+		return s, err
+	}
+	s.Enabled = sd.Enabled
+	s.Temperature = sd.SetbackTemp
+
+	for day := range s.Days {
+		psd, err := b.GetProgSetbackDataContext(ctx, loadNum, time.Weekday(day))
+		if err != nil {
+			return s, err
+		}
+		for _, e := range psd.Events {
+			s.Days[day] = append(s.Days[day], SetbackWindow{Start: TimeOfDay(e.Start), End: TimeOfDay(e.End), Temperature: e.SetbackTemp})
+		}
+	}
+	return s, nil
+}
+
+// SetSetbackSchedule writes the weekly setback schedule for the load in s. The schedule is validated before it
+// is written and read back afterwards, and a *VerifyError is returned if it does not match. Writes must be allowed
+// on the Boiler. A WriteResult is returned for each object written, including those written before a write fails.
+// Setback is enabled after the windows are written, and disabled before, so a failed write does not enable a
+// partly written schedule.
+func (b Boiler) SetSetbackSchedule(s SetbackSchedule) ([]WriteResult, error) {
+	return b.SetSetbackScheduleContext(context.Background(), s)
+}
+
+// SetSetbackScheduleContext writes the weekly setback schedule for the load in s using the provided context. See SetSetbackSchedule.
+func (b Boiler) SetSetbackScheduleContext(ctx context.Context, s SetbackSchedule) ([]WriteResult, error) {
+	results := make([]WriteResult, 0, len(s.Days)+1)
+	if err := s.Validate(); err != nil {
+		return results, err
+	}
+
+	type object struct {
+		request int
+		data    interface{}
+	}
+	objects := make([]object, 0, len(s.Days)+1)
+	for day, windows := range s.Days {
+		psd := ProgSetbackData{Load: s.Load - 1, Day: day, Events: make([]ProgSetbackEvent, 0, len(windows))}
+		for _, w := range sortedWindows(windows) {
+			psd.Events = append(psd.Events, ProgSetbackEvent{Start: int(w.Start), End: int(w.End), SetbackTemp: w.Temperature})
+		}
+		objects = append(objects, object{ReqProgSetbackData, psd})
+	}
+	sd := object{ReqBoilerSetbackData, SetbackData{Load: s.Load - 1, Enabled: s.Enabled, SetbackTemp: s.Temperature}}
+	if s.Enabled {
+		objects = append(objects, sd)
+	} else {
+		objects = append([]object{sd}, objects...)
+	}
+
+	var res WriteResult
+	for _, obj := range objects {
+		var err error
+		res, err = b.writeData(ctx, obj.request, obj.data)
+		results = append(results, res)
+		if err != nil {
+			return results, err
+		}
+	}
+	if !res.Sent {
+		return results, nil
+	}
+
+	got, err := b.GetSetbackScheduleContext(ctx, s.Load)
+	if err != nil {
+		return results, err
+	}
+	if !reflect.DeepEqual(got.normalize(), s.normalize()) {
+		return results, &VerifyError{Request: ReqProgSetbackData, Written: s.Format(Metric), Read: got.Format(Metric)}
+	}
+	for i := range results {
+		results[i].Verified = true
+	}
+	return results, nil
+}
+
+// normalize sorts the windows of each day and drops empty days so schedules can be compared.
+func (s SetbackSchedule) normalize() SetbackSchedule {
+	for day, windows := range s.Days {
+		if len(windows) == 0 {
+			s.Days[day] = nil
+			continue
+		}
+		s.Days[day] = sortedWindows(windows)
+	}
+	return s
+}
+
+// SetbackPlan is a set of setback schedules in the form used by schedule files, so the same schedule can be
+// applied at every site. The field tags allow a plan to be decoded from YAML or JSON.
+type SetbackPlan struct {
+	Loads []SetbackPlanLoad `yaml:"loads" json:"loads"`
+}
+
+// SetbackPlanLoad is the schedule for one load in a SetbackPlan.
+//
+// Temperature is the default setback temperature, ex "60F". Days maps a day name, or "daily", "weekdays" or
+// "weekends", to the windows for those days. Windows are written as "HH:MM-HH:MM" and may be followed by a
+// temperature for that window, ex "22:00-24:00 55F". A named day replaces the windows from a group.
+type SetbackPlanLoad struct {
+	Load        int                 `yaml:"load" json:"load"`
+	Enabled     bool                `yaml:"enabled" json:"enabled"`
+	Temperature string              `yaml:"temperature" json:"temperature"`
+	Days        map[string][]string `yaml:"days" json:"days"`
+}
+
+var setbackDayGroups = map[string][]time.Weekday{
+	"daily":    {time.Sunday, time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday, time.Saturday},
+	"weekdays": {time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday},
+	"weekends": {time.Saturday, time.Sunday},
+}
+
+// Schedules returns the validated SetbackSchedule for each load in the plan. An error is returned if a load
+// is listed more than once.
+func (p SetbackPlan) Schedules() ([]SetbackSchedule, error) {
+	schedules := make([]SetbackSchedule, 0, len(p.Loads))
+	seen := make(map[int]bool, len(p.Loads))
+	for _, pl := range p.Loads {
+		if seen[pl.Load] {
+			return nil, fmt.Errorf("ibc: load %d is listed more than once in setback plan", pl.Load)
+		}
+		seen[pl.Load] = true
+		s, err := pl.schedule()
+		if err != nil {
+			return nil, fmt.Errorf("%w in setback plan for load %d", err, pl.Load)
+		}
+		schedules = append(schedules, s)
+	}
+	return schedules, nil
+}
+
+func (pl SetbackPlanLoad) schedule() (SetbackSchedule, error) {
+	s := SetbackSchedule{Load: pl.Load, Enabled: pl.Enabled}
+	var err error
+	if s.Temperature, err = ParseTemperature(pl.Temperature); err != nil {
+		return s, err
+	}
+
+	// Apply the groups first, in a fixed order, so named days replace them.
+	days := make(map[string][]string, len(pl.Days))
+	var named []string
+	for key, windows := range pl.Days {
+		key = strings.ToLower(key)
+		days[key] = windows
+		if _, ok := setbackDayGroups[key]; !ok {
+			named = append(named, key)
+		}
+	}
+	for _, key := range []string{"daily", "weekdays", "weekends"} {
+		if windows, ok := days[key]; ok {
+			if err := s.setDays(setbackDayGroups[key], windows); err != nil {
+				return s, err
+			}
+		}
+	}
+	sort.Strings(named)
+	seen := make(map[time.Weekday]string, len(named))
+	for _, key := range named {
+		day, ok := parseWeekday(key)
+		if !ok {
+			return s, fmt.Errorf("ibc: unknown day %q", key)
+		}
+		if other, ok := seen[day]; ok {
+			return s, fmt.Errorf("ibc: %q and %q are both %s", other, key, day)
+		}
+		seen[day] = key
+		if err := s.setDays([]time.Weekday{day}, days[key]); err != nil {
+			return s, err
+		}
+	}
+	return s, s.Validate()
+}
+
+// setDays parses windows and sets them as the windows of each of days.
+func (s *SetbackSchedule) setDays(days []time.Weekday, windows []string) error {
+	parsed := make([]SetbackWindow, 0, len(windows))
+	for _, w := range windows {
+		sw, err := s.parseWindow(w)
+		if err != nil {
+			return err
+		}
+		parsed = append(parsed, sw)
+	}
+	for _, day := range days {
+		s.Days[day] = parsed
+	}
+	return nil
+}
+
+// parseWindow parses a window in the form "HH:MM-HH:MM [temperature]".
+func (s SetbackSchedule) parseWindow(w string) (SetbackWindow, error) {
+	sw := SetbackWindow{Temperature: s.Temperature}
+	fields := strings.Fields(w)
+	if len(fields) < 1 || len(fields) > 2 {
+		return sw, fmt.Errorf("ibc: invalid setback window %q, must be HH:MM-HH:MM [temperature]", w)
+	}
+	times := strings.SplitN(fields[0], "-", 2)
+	if len(times) != 2 {
+		return sw, fmt.Errorf("ibc: invalid setback window %q, must be HH:MM-HH:MM [temperature]", w)
+	}
+	var err error
+	if sw.Start, err = ParseTimeOfDay(times[0]); err != nil {
+		return sw, err
+	}
+	if sw.End, err = ParseTimeOfDay(times[1]); err != nil {
+		return sw, err
+	}
+	if len(fields) == 2 {
+		if sw.Temperature, err = ParseTemperature(fields[1]); err != nil {
+			return sw, err
+		}
+	}
+	return sw, nil
+}
+
+// parseWeekday parses a day name or an abbreviation of at least three letters, ex "Tue", "Tues" or "Thurs".
+func parseWeekday(s string) (time.Weekday, bool) {
+	s = strings.ToLower(s)
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		if len(s) >= 3 && strings.HasPrefix(strings.ToLower(day.String()), s) {
+			return day, true
+		}
+	}
+	return 0, false
+}
+
+// ApplySetbackPlan writes the schedule for each load in the plan. The whole plan is validated before anything is
+// written. If a write fails, the loads after it are not written, and the results of the writes made so far are
+// returned with an error naming the load. See SetSetbackSchedule.
+func (b Boiler) ApplySetbackPlan(p SetbackPlan) ([]WriteResult, error) {
+	return b.ApplySetbackPlanContext(context.Background(), p)
+}
+
+// ApplySetbackPlanContext writes the schedule for each load in the plan using the provided context. See ApplySetbackPlan.
+func (b Boiler) ApplySetbackPlanContext(ctx context.Context, p SetbackPlan) ([]WriteResult, error) {
+	schedules, err := p.Schedules()
+	if err != nil {
+		return nil, err
+	}
+	var results []WriteResult
+	for _, s := range schedules {
+		res, err := b.SetSetbackScheduleContext(ctx, s)
+		results = append(results, res...)
+		if err != nil {
+			return results, fmt.Errorf("%w applying setback plan for load %d", err, s.Load)
+		}
+	}
+	return results, nil
+}
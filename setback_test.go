@@ -0,0 +1,195 @@
+package ibc
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+// setbackObjects returns the setback objects of a boiler with setback disabled and no windows on every load.
+func setbackObjects() []fakeObject {
+	var objects []fakeObject
+	for load := 1; load <= 4; load++ {
+		objects = append(objects, fakeObject{ReqBoilerSetbackData, load, 0,
+			fmt.Sprintf(`{"rbid":0,"object_no":14,"Load":%d,"Enabled":false,"SetbackT":240}`, load-1)})
+		for day := 0; day < 7; day++ {
+			objects = append(objects, fakeObject{ReqProgSetbackData, load, day,
+				fmt.Sprintf(`{"rbid":0,"object_no":50,"Load":%d,"Day":%d,"Events":[]}`, load-1, day)})
+		}
+	}
+	return objects
+}
+
+func TestParseTimeOfDay(t *testing.T) {
+	tests := []struct {
+		in   string
+		want TimeOfDay
+		ok   bool
+	}{
+		{"06:30", 390, true},
+		{"0:00", 0, true},
+		{"24:00", 1440, true},
+		{"24:01", 0, false},
+		{"12:60", 0, false},
+		{"noon", 0, false},
+	}
+	for _, test := range tests {
+		got, err := ParseTimeOfDay(test.in)
+		if (err == nil) != test.ok || got != test.want {
+			t.Errorf("ParseTimeOfDay(%q) is incorrect, got: %v %v, want: %v.", test.in, got, err, test.want)
+		}
+	}
+	if got := TimeOfDay(390).String(); got != "06:30" {
+		t.Errorf("TimeOfDay String is incorrect, got: %s, want: %s.", got, "06:30")
+	}
+}
+
+func TestSetbackScheduleValidate(t *testing.T) {
+	window := func(start, end int) SetbackWindow {
+		return SetbackWindow{Start: TimeOfDay(start), End: TimeOfDay(end), Temperature: TemperatureFromF(140)}
+	}
+	tests := []struct {
+		name    string
+		windows []SetbackWindow
+		ok      bool
+	}{
+		{"valid", []SetbackWindow{window(1320, 1440), window(0, 390)}, true},
+		{"empty", []SetbackWindow{window(390, 390)}, false},
+		{"overlap", []SetbackWindow{window(0, 400), window(390, 500)}, false},
+		{"too many", []SetbackWindow{window(0, 10), window(20, 30), window(40, 50), window(60, 70), window(80, 90)}, false},
+		{"too cold", []SetbackWindow{{Start: 0, End: 10, Temperature: TemperatureFromF(20)}}, false},
+	}
+	for _, test := range tests {
+		s := SetbackSchedule{Load: 1, Temperature: TemperatureFromF(140)}
+		s.Days[time.Monday] = test.windows
+		if err := s.Validate(); (err == nil) != test.ok {
+			t.Errorf("Validate %s is incorrect, got: %v, want ok: %v.", test.name, err, test.ok)
+		}
+	}
+}
+
+func TestSetbackPlanSchedules(t *testing.T) {
+	p := SetbackPlan{Loads: []SetbackPlanLoad{{
+		Load:        2,
+		Enabled:     true,
+		Temperature: "140F",
+		Days: map[string][]string{
+			"Weekdays": {"00:00-06:00", "22:00-24:00"},
+			"weekends": {"00:00-08:00"},
+			"fri":      {"00:00-06:00 130F"},
+		},
+	}}}
+	schedules, err := p.Schedules()
+	if err != nil {
+		t.Fatalf("Schedules returned error: %v", err)
+	}
+	s := schedules[0]
+	if s.Load != 2 || !s.Enabled || s.Temperature != TemperatureFromF(140) {
+		t.Errorf("Schedule is incorrect, got: %+v.", s)
+	}
+	if len(s.Days[time.Monday]) != 2 || len(s.Days[time.Sunday]) != 1 || s.Days[time.Sunday][0].End != 480 {
+		t.Errorf("Schedule days are incorrect, got: %v.", s.Days)
+	}
+	if len(s.Days[time.Friday]) != 1 || s.Days[time.Friday][0].Temperature != TemperatureFromF(130) {
+		t.Errorf("Friday overrides weekdays incorrectly, got: %v.", s.Days[time.Friday])
+	}
+
+	p.Loads[0].Days = map[string][]string{"Tues": {"00:00-06:00"}, "thurs": {"22:00-24:00"}, "Wed": {"01:00-02:00"}}
+	if schedules, err = p.Schedules(); err != nil {
+		t.Fatalf("Schedules with abbreviated days returned error: %v", err)
+	}
+	if days := schedules[0].Days; len(days[time.Tuesday]) != 1 || len(days[time.Thursday]) != 1 || len(days[time.Wednesday]) != 1 {
+		t.Errorf("Schedule days are incorrect, got: %v.", days)
+	}
+
+	invalid := []map[string][]string{
+		{"funday": {"00:00-06:00"}},
+		{"tu": {"00:00-06:00"}},
+		{"Mon": {"00:00-06:00"}, "monday": {"22:00-24:00"}},
+	}
+	for _, days := range invalid {
+		p.Loads[0].Days = days
+		if _, err := p.Schedules(); err == nil || !strings.Contains(err.Error(), "load 2") {
+			t.Errorf("Schedules with days %v is incorrect, got: %v.", days, err)
+		}
+	}
+
+	p.Loads[0].Days = nil
+	p.Loads = append(p.Loads, p.Loads[0])
+	if _, err := p.Schedules(); err == nil {
+		t.Error("Schedules with a load listed twice returned no error.")
+	}
+}
+
+func TestApplySetbackPlanFailure(t *testing.T) {
+	b, fb, done := newFakeBoiler(setbackObjects()...)
+	defer done()
+	b.AllowWrites = true
+	fb.failWrite = func(obj map[string]interface{}) bool {
+		return obj["Load"] == float64(1) && obj["Day"] == float64(time.Wednesday)
+	}
+
+	p := SetbackPlan{Loads: []SetbackPlanLoad{
+		{Load: 1, Enabled: true, Temperature: "140F", Days: map[string][]string{"daily": {"00:00-06:00"}}},
+		{Load: 2, Enabled: true, Temperature: "140F", Days: map[string][]string{"daily": {"00:00-06:00"}}},
+		{Load: 3, Enabled: true, Temperature: "140F", Days: map[string][]string{"daily": {"00:00-06:00"}}},
+	}}
+	results, err := b.ApplySetbackPlan(p)
+	var statusErr *HTTPStatusError
+	if !errors.As(err, &statusErr) || !strings.Contains(err.Error(), "load 2") {
+		t.Errorf("ApplySetbackPlan error is incorrect, got: %v, want: *HTTPStatusError for load 2.", err)
+	}
+	// Load 1 is written and verified, then load 2 fails on its fourth window write.
+	if len(results) != 12 || !results[7].Verified || results[11].Sent || !results[10].Sent {
+		t.Errorf("ApplySetbackPlan results are incorrect, got: %+v.", results)
+	}
+	if s, err := b.GetSetbackSchedule(2); err != nil || s.Enabled {
+		t.Errorf("Load 2 setback after a failed write is incorrect, got: %+v %v, want: not enabled.", s, err)
+	}
+	if s, err := b.GetSetbackSchedule(3); err != nil || len(s.Days[time.Monday]) != 0 {
+		t.Errorf("Load 3 was written after a failed write, got: %+v %v.", s, err)
+	}
+}
+
+func TestSetSetbackSchedule(t *testing.T) {
+	b, fb, done := newFakeBoiler(setbackObjects()...)
+	defer done()
+	b.AllowWrites = true
+
+	s := SetbackSchedule{Load: 3, Enabled: true, Temperature: TemperatureFromC(60)}
+	s.Days[time.Tuesday] = []SetbackWindow{
+		{Start: 1320, End: 1440, Temperature: TemperatureFromC(55)},
+		{Start: 0, End: 360, Temperature: TemperatureFromC(60)},
+	}
+	results, err := b.SetSetbackSchedule(s)
+	if err != nil {
+		t.Fatalf("SetSetbackSchedule returned error: %v", err)
+	}
+	if len(results) != 8 || fb.writeCount() != 8 || !results[7].Verified {
+		t.Errorf("SetSetbackSchedule results are incorrect, got: %d results, %d writes, %+v.", len(results), fb.writeCount(), results[7])
+	}
+
+	got, err := b.GetSetbackSchedule(3)
+	if err != nil {
+		t.Fatalf("GetSetbackSchedule returned error: %v", err)
+	}
+	if !got.Enabled || len(got.Days[time.Tuesday]) != 2 || got.Days[time.Tuesday][0].Start != 0 || len(got.Days[time.Monday]) != 0 {
+		t.Errorf("Schedule after write is incorrect, got: %+v.", got)
+	}
+	want := "Tuesday:   00:00-06:00 60.0°C, 22:00-24:00 55.0°C\n"
+	if text := got.Format(Metric); !strings.Contains(text, want) {
+		t.Errorf("Format is incorrect, got: %s, want line: %s", text, want)
+	}
+}
+
+func TestSetSetbackScheduleRequiresOptIn(t *testing.T) {
+	b, fb, done := newFakeBoiler(setbackObjects()...)
+	defer done()
+
+	_, err := b.SetSetbackSchedule(SetbackSchedule{Load: 1, Temperature: TemperatureFromF(140)})
+	if !errors.Is(err, ErrWritesDisabled) || fb.writeCount() != 0 {
+		t.Errorf("SetSetbackSchedule without AllowWrites is incorrect, got: %v after %d writes, want: %v.", err, fb.writeCount(), ErrWritesDisabled)
+	}
+}
@@ -0,0 +1,189 @@
+package ibc
+
+import (
+	"context"
+	"fmt"
+)
+
+// PumpMode selects when a pump runs, as set in BoilerAdvSettingsData.
+type PumpMode int
+
+// Pump Mode Constants
+const (
+	PumpWithDemand       PumpMode = 0
+	PumpContinuous       PumpMode = 1
+	PumpUntilWarmWeather PumpMode = 2
+)
+
+var pumpModeNames = [...]string{"With Demand", "Continuous", "Until Warm Weather Shutdown"}
+
+func (m PumpMode) String() string {
+	if m < 0 || int(m) >= len(pumpModeNames) {
+		return fmt.Sprintf("Unknown (%d)", int(m))
+	}
+	return pumpModeNames[m]
+}
+
+// AuxSensorMode selects what the Sec/Indoor sensor input measures, as set in BoilerAdvSettingsData.
+type AuxSensorMode int
+
+// Aux Sensor Mode Constants
+const (
+	AuxSensorNone         AuxSensorMode = 0
+	AuxSensorSystemSupply AuxSensorMode = 1
+	AuxSensorIndoor       AuxSensorMode = 2
+)
+
+var auxSensorModeNames = [...]string{"None", "System Supply", "Indoor"}
+
+func (m AuxSensorMode) String() string {
+	if m < 0 || int(m) >= len(auxSensorModeNames) {
+		return fmt.Sprintf("Unknown (%d)", int(m))
+	}
+	return auxSensorModeNames[m]
+}
+
+// BoilerAdvSettingsData represents the data returned by the ReqBoilerAdvSetttingsData request.
+type BoilerAdvSettingsData struct {
+	//"rbid": 0
+	//"object_no": 15
+	MaxBoilerTemp Temperature `json:"MaxBoilerT"`
+	// MinFire and MaxFire limit the firing rate, as a percentage of the boiler's capacity.
+	MinFire int `json:"MinFire"`
+	MaxFire int `json:"MaxFire"`
+	// AntiCycle is the minimum time between burner cycles, in minutes.
+	AntiCycle int `json:"AntiCycle"`
+	// PumpOverrun is how long the boiler pump runs after the burner stops, in seconds.
+	PumpOverrun    int           `json:"PumpOverrun"`
+	BoilerPump     PumpMode      `json:"BoilerPump"`
+	SystemPump     PumpMode      `json:"SysPump"`
+	FreezeProtect  Temperature   `json:"FreezeT"`
+	AuxSensor      AuxSensorMode `json:"AuxSensor"`
+	OutdoorSensor  bool          `json:"OutdoorSensor"`
+	DHWPriorityMin int           `json:"DHWPriorityMin"`
+}
+
+// CascadeMode selects how the boilers on a cascade network share the load, as set in BoilerMultiSettingData.
+type CascadeMode int
+
+// Cascade Mode Constants
+const (
+	CascadeOff      CascadeMode = 0
+	CascadeLeadLag  CascadeMode = 1
+	CascadeParallel CascadeMode = 2
+)
+
+var cascadeModeNames = [...]string{"Off", "Lead/Lag", "Parallel"}
+
+func (m CascadeMode) String() string {
+	if m < 0 || int(m) >= len(cascadeModeNames) {
+		return fmt.Sprintf("Unknown (%d)", int(m))
+	}
+	return cascadeModeNames[m]
+}
+
+// RotationMode selects how the lead boiler of a cascade is rotated, as set in BoilerMultiSettingData.
+type RotationMode int
+
+// Rotation Mode Constants
+const (
+	RotateFixed    RotationMode = 0
+	RotateRunHours RotationMode = 1
+	RotateDaily    RotationMode = 2
+)
+
+var rotationModeNames = [...]string{"Fixed", "By Run Hours", "Daily"}
+
+func (m RotationMode) String() string {
+	if m < 0 || int(m) >= len(rotationModeNames) {
+		return fmt.Sprintf("Unknown (%d)", int(m))
+	}
+	return rotationModeNames[m]
+}
+
+// BoilerMultiSettingData represents the data returned by the ReqBoilerMultiSettingData request.
+type BoilerMultiSettingData struct {
+	//"rbid": 0
+	//"object_no": 17
+	Mode       CascadeMode  `json:"Mode"`
+	Rotation   RotationMode `json:"Rotation"`
+	RotateHrs  int          `json:"RotateHrs"`
+	MaxBoilers int          `json:"MaxBoilers"`
+	// StageDelay is the time to wait before firing another boiler, in seconds.
+	StageDelay int `json:"StageDelay"`
+	// StageUp and StageDown are the cascade firing rates, in percent, at which a boiler is added or dropped.
+	StageUp   int `json:"StageUp"`
+	StageDown int `json:"StageDown"`
+}
+
+// CleaningMode selects when the boiler reminds that it is due for cleaning, as set in BoilerCleaningSettingData.
+type CleaningMode int
+
+// Cleaning Mode Constants
+const (
+	CleaningOff         CleaningMode = 0
+	CleaningBurnerHours CleaningMode = 1
+	CleaningMonths      CleaningMode = 2
+)
+
+var cleaningModeNames = [...]string{"Off", "Burner Hours", "Months"}
+
+func (m CleaningMode) String() string {
+	if m < 0 || int(m) >= len(cleaningModeNames) {
+		return fmt.Sprintf("Unknown (%d)", int(m))
+	}
+	return cleaningModeNames[m]
+}
+
+// BoilerCleaningSettingData represents the data returned by the ReqBoilerCleaningSettingData request.
+type BoilerCleaningSettingData struct {
+	//"rbid": 0
+	//"object_no": 18
+	Mode CleaningMode `json:"Mode"`
+	// Interval is the time between cleanings, in burner hours or months depending on Mode.
+	Interval int `json:"Interval"`
+	// Elapsed is the time since the last cleaning, in the same units as Interval.
+	Elapsed  int  `json:"Elapsed"`
+	Reminder bool `json:"Reminder"`
+}
+
+// Due returns true if cleaning reminders are on and the interval has elapsed.
+func (cd BoilerCleaningSettingData) Due() bool {
+	return cd.Mode != CleaningOff && cd.Elapsed >= cd.Interval
+}
+
+// GetBoilerAdvSettingsData returns the BoilerAdvSettingsData for the current boiler.
+func (b Boiler) GetBoilerAdvSettingsData() (BoilerAdvSettingsData, error) {
+	return b.GetBoilerAdvSettingsDataContext(context.Background())
+}
+
+// GetBoilerAdvSettingsDataContext returns the BoilerAdvSettingsData for the current boiler using the provided context.
+func (b Boiler) GetBoilerAdvSettingsDataContext(ctx context.Context) (BoilerAdvSettingsData, error) {
+	reqObj := requestObject{ObjectNum: 100, ObjectRequest: ReqBoilerAdvSetttingsData, BoilerNum: b.BoilerNum}
+	var respObj = BoilerAdvSettingsData{}
+	return respObj, b.getData(ctx, reqObj, &respObj)
+}
+
+// GetBoilerMultiSettingData returns the BoilerMultiSettingData for the current boiler.
+func (b Boiler) GetBoilerMultiSettingData() (BoilerMultiSettingData, error) {
+	return b.GetBoilerMultiSettingDataContext(context.Background())
+}
+
+// GetBoilerMultiSettingDataContext returns the BoilerMultiSettingData for the current boiler using the provided context.
+func (b Boiler) GetBoilerMultiSettingDataContext(ctx context.Context) (BoilerMultiSettingData, error) {
+	reqObj := requestObject{ObjectNum: 100, ObjectRequest: ReqBoilerMultiSettingData, BoilerNum: b.BoilerNum}
+	var respObj = BoilerMultiSettingData{}
+	return respObj, b.getData(ctx, reqObj, &respObj)
+}
+
+// GetBoilerCleaningSettingData returns the BoilerCleaningSettingData for the current boiler.
+func (b Boiler) GetBoilerCleaningSettingData() (BoilerCleaningSettingData, error) {
+	return b.GetBoilerCleaningSettingDataContext(context.Background())
+}
+
+// GetBoilerCleaningSettingDataContext returns the BoilerCleaningSettingData for the current boiler using the provided context.
+func (b Boiler) GetBoilerCleaningSettingDataContext(ctx context.Context) (BoilerCleaningSettingData, error) {
+	reqObj := requestObject{ObjectNum: 100, ObjectRequest: ReqBoilerCleaningSettingData, BoilerNum: b.BoilerNum}
+	var respObj = BoilerCleaningSettingData{}
+	return respObj, b.getData(ctx, reqObj, &respObj)
+}
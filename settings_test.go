@@ -0,0 +1,119 @@
+package ibc
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+var updateGolden = flag.Bool("update", false, "update the .golden files in testdata")
+
+// testGolden decodes a captured response from testdata into v and compares it with the matching .golden file.
+// Fields in the response that v does not decode, and fields of v missing from the response, fail the test so
+// firmware changes that add or rename fields are caught. The rbid and object_no keys the boiler tags every
+// response with are not decoded.
+func testGolden(t *testing.T, name string, v interface{}) {
+	t.Helper()
+	body, err := ioutil.ReadFile(filepath.Join("testdata", name+".json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var fields map[string]interface{}
+	if err := json.Unmarshal(body, &fields); err != nil {
+		t.Fatalf("Decoding %s is incorrect, got: %v.", name, err)
+	}
+	for k := range envelopeKeys {
+		delete(fields, k)
+	}
+	body, _ = json.Marshal(fields)
+
+	dec := json.NewDecoder(bytes.NewReader(body))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(v); err != nil {
+		t.Fatalf("Decoding %s is incorrect, got: %v.", name, err)
+	}
+
+	rt := reflect.TypeOf(v).Elem()
+	for i := 0; i < rt.NumField(); i++ {
+		f := rt.Field(i)
+		if _, ok := fields[f.Tag.Get("json")]; !ok {
+			t.Errorf("Field %s (%s) is missing from %s.", f.Name, f.Tag.Get("json"), name)
+		}
+	}
+
+	got := fmt.Sprintf("%+v\n", reflect.ValueOf(v).Elem().Interface())
+	golden := filepath.Join("testdata", name+".golden")
+	if *updateGolden {
+		if err := ioutil.WriteFile(golden, []byte(got), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	want, err := ioutil.ReadFile(golden)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != string(want) {
+		t.Errorf("%s is incorrect, got: %s, want: %s.", name, got, want)
+	}
+}
+
+func TestBoilerAdvSettingsDataGolden(t *testing.T) {
+	var asd BoilerAdvSettingsData
+	testGolden(t, "adv_settings_data", &asd)
+}
+
+func TestBoilerMultiSettingDataGolden(t *testing.T) {
+	var msd BoilerMultiSettingData
+	testGolden(t, "multi_setting_data", &msd)
+}
+
+func TestBoilerCleaningSettingDataGolden(t *testing.T) {
+	var csd BoilerCleaningSettingData
+	testGolden(t, "cleaning_setting_data", &csd)
+}
+
+func TestGetSettingsData(t *testing.T) {
+	b, done := newFixtureBoiler(t, map[int]string{
+		ReqBoilerAdvSetttingsData:    "adv_settings_data.json",
+		ReqBoilerMultiSettingData:    "multi_setting_data.json",
+		ReqBoilerCleaningSettingData: "cleaning_setting_data.json",
+	})
+	defer done()
+
+	asd, err := b.GetBoilerAdvSettingsData()
+	if err != nil || asd.SystemPump != PumpUntilWarmWeather || asd.AuxSensor != AuxSensorSystemSupply {
+		t.Errorf("GetBoilerAdvSettingsData is incorrect, got: %+v %v.", asd, err)
+	}
+	msd, err := b.GetBoilerMultiSettingData()
+	if err != nil || msd.Mode != CascadeLeadLag || msd.Rotation != RotateRunHours {
+		t.Errorf("GetBoilerMultiSettingData is incorrect, got: %+v %v.", msd, err)
+	}
+	csd, err := b.GetBoilerCleaningSettingData()
+	if err != nil || csd.Mode != CleaningBurnerHours || csd.Due() {
+		t.Errorf("GetBoilerCleaningSettingData is incorrect, got: %+v %v.", csd, err)
+	}
+}
+
+func TestSettingsEnumString(t *testing.T) {
+	tests := []struct {
+		got  fmt.Stringer
+		want string
+	}{
+		{PumpContinuous, "Continuous"},
+		{AuxSensorIndoor, "Indoor"},
+		{CascadeParallel, "Parallel"},
+		{RotateDaily, "Daily"},
+		{CleaningMonths, "Months"},
+		{CascadeMode(7), "Unknown (7)"},
+	}
+	for _, test := range tests {
+		if test.got.String() != test.want {
+			t.Errorf("String is incorrect, got: %v, want: %v.", test.got.String(), test.want)
+		}
+	}
+}
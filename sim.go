@@ -0,0 +1,150 @@
+package ibc
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// SIMStatus is the state of the spark ignition module.
+type SIMStatus int
+
+// SIM Status Constants
+const (
+	SIMIdle     SIMStatus = 0
+	SIMSparking SIMStatus = 1
+	SIMProving  SIMStatus = 2
+	SIMFlameOn  SIMStatus = 3
+	SIMLockout  SIMStatus = 4
+)
+
+var simStatusNames = [...]string{"Idle", "Sparking", "Proving Flame", "Flame On", "Lockout"}
+
+func (s SIMStatus) String() string {
+	if s < 0 || int(s) >= len(simStatusNames) {
+		return fmt.Sprintf("Unknown (%d)", int(s))
+	}
+	return simStatusNames[s]
+}
+
+// BoilerSIMData represents the data returned by the ReqBoilerSIMData request.
+type BoilerSIMData struct {
+	//"rbid": 0
+	//"object_no": 44
+	Status SIMStatus `json:"SIM_Status"`
+	// Flame is the flame signal measured by the SIM. A falling signal usually means a dirty igniter or flame rod.
+	Flame int `json:"SIM_Flame"`
+	// FlameSense is the flame signal measured by the boiler control board.
+	FlameSense int  `json:"FlameSense"`
+	Spark      bool `json:"Spark"`
+	GasValve   bool `json:"GasValve"`
+	// Trials and Lockouts count the ignition trials and lockouts since power up.
+	Trials   int `json:"Trials"`
+	Lockouts int `json:"Lockouts"`
+}
+
+// FlameSample returns the flame signal as a sample taken at t. It returns false if the flame is not on,
+// as the signal is only meaningful while the burner is lit.
+func (sd BoilerSIMData) FlameSample(t time.Time) (FlameSample, bool) {
+	if sd.Status != SIMFlameOn {
+		return FlameSample{}, false
+	}
+	return FlameSample{Time: t, Signal: sd.Flame}, true
+}
+
+// GetBoilerSIMData returns the BoilerSIMData for the current boiler.
+func (b Boiler) GetBoilerSIMData() (BoilerSIMData, error) {
+	return b.GetBoilerSIMDataContext(context.Background())
+}
+
+// GetBoilerSIMDataContext returns the BoilerSIMData for the current boiler using the provided context.
+func (b Boiler) GetBoilerSIMDataContext(ctx context.Context) (BoilerSIMData, error) {
+	reqObj := requestObject{ObjectNum: 100, ObjectRequest: ReqBoilerSIMData, BoilerNum: b.BoilerNum}
+	var respObj = BoilerSIMData{}
+	return respObj, b.getData(ctx, reqObj, &respObj)
+}
+
+// FlameSample is a flame signal reading taken at a point in time.
+type FlameSample struct {
+	Time   time.Time
+	Signal int
+}
+
+// FlameSamplesFromErrorLog returns the SIM flame signal recorded with each error log entry that has a time and a signal.
+func FlameSamplesFromErrorLog(entries []ErrorLogEntry) []FlameSample {
+	samples := make([]FlameSample, 0, len(entries))
+	for _, e := range entries {
+		if e.Time.IsZero() || e.Data.SIMFlame <= 0 {
+			continue
+		}
+		samples = append(samples, FlameSample{Time: e.Time, Signal: e.Data.SIMFlame})
+	}
+	return samples
+}
+
+// FlameTrend summarizes a series of flame signal samples.
+type FlameTrend struct {
+	Samples     int
+	First, Last time.Time
+	Min, Max    int
+	Mean        float64
+	// PerDay is the least squares change in signal per day. It is negative when the signal is weakening.
+	PerDay float64
+}
+
+// NewFlameTrend returns the trend of the samples, which need not be in order.
+func NewFlameTrend(samples []FlameSample) FlameTrend {
+	var ft FlameTrend
+	if len(samples) == 0 {
+		return ft
+	}
+	sorted := append([]FlameSample(nil), samples...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Time.Before(sorted[j].Time) })
+
+	ft.Samples = len(sorted)
+	ft.First, ft.Last = sorted[0].Time, sorted[len(sorted)-1].Time
+	ft.Min, ft.Max = sorted[0].Signal, sorted[0].Signal
+	var sumX, sumY float64
+	for _, s := range sorted {
+		if s.Signal < ft.Min {
+			ft.Min = s.Signal
+		}
+		if s.Signal > ft.Max {
+			ft.Max = s.Signal
+		}
+		sumX += s.Time.Sub(ft.First).Hours() / 24
+		sumY += float64(s.Signal)
+	}
+	n := float64(len(sorted))
+	ft.Mean = sumY / n
+
+	meanX := sumX / n
+	var sxx, sxy float64
+	for _, s := range sorted {
+		dx := s.Time.Sub(ft.First).Hours()/24 - meanX
+		sxx += dx * dx
+		sxy += dx * (float64(s.Signal) - ft.Mean)
+	}
+	if sxx > 0 {
+		ft.PerDay = sxy / sxx
+	}
+	return ft
+}
+
+// Weakening returns true if the signal is falling by more than fraction of its mean every 30 days,
+// ex Weakening(0.1) is true for a signal losing more than 10% a month.
+func (ft FlameTrend) Weakening(fraction float64) bool {
+	if ft.Mean <= 0 {
+		return false
+	}
+	return -ft.PerDay*30/ft.Mean > fraction
+}
+
+func (ft FlameTrend) String() string {
+	if ft.Samples == 0 {
+		return "No flame signal samples"
+	}
+	return fmt.Sprintf("%d samples from %s to %s, signal %d-%d, mean %.1f, %+.2f per day", ft.Samples,
+		ft.First.Format("2006-01-02"), ft.Last.Format("2006-01-02"), ft.Min, ft.Max, ft.Mean, ft.PerDay)
+}
@@ -0,0 +1,67 @@
+package ibc
+
+import (
+	"math"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestGetBoilerSIMData(t *testing.T) {
+	b, done := newTestBoiler(func(req requestObject) (int, string) {
+		return http.StatusOK, `{"SIM_Status":3,"SIM_Flame":42,"FlameSense":40,"Spark":false,"GasValve":true,"Trials":12,"Lockouts":1}`
+	})
+	defer done()
+
+	sd, err := b.GetBoilerSIMData()
+	if err != nil {
+		t.Fatalf("GetBoilerSIMData returned error: %v", err)
+	}
+	if sd.Status != SIMFlameOn || sd.Status.String() != "Flame On" || sd.Flame != 42 || !sd.GasValve {
+		t.Errorf("GetBoilerSIMData is incorrect, got: %+v.", sd)
+	}
+	now := time.Now()
+	if s, ok := sd.FlameSample(now); !ok || s.Signal != 42 || !s.Time.Equal(now) {
+		t.Errorf("FlameSample is incorrect, got: %+v %v.", s, ok)
+	}
+	sd.Status = SIMIdle
+	if _, ok := sd.FlameSample(now); ok {
+		t.Errorf("FlameSample returned a sample while the flame is off.")
+	}
+}
+
+func TestFlameTrend(t *testing.T) {
+	start := time.Date(2019, time.January, 1, 0, 0, 0, 0, time.UTC)
+	var samples []FlameSample
+	// A signal losing 1 a day from 60, sampled every 10 days, out of order.
+	for _, day := range []int{30, 0, 20, 10} {
+		samples = append(samples, FlameSample{Time: start.AddDate(0, 0, day), Signal: 60 - day})
+	}
+
+	ft := NewFlameTrend(samples)
+	if ft.Samples != 4 || !ft.First.Equal(start) || ft.Min != 30 || ft.Max != 60 || ft.Mean != 45 {
+		t.Errorf("FlameTrend is incorrect, got: %+v.", ft)
+	}
+	if math.Abs(ft.PerDay+1) > 1e-9 {
+		t.Errorf("FlameTrend PerDay is incorrect, got: %v, want: %v.", ft.PerDay, -1)
+	}
+	// 30 a month is 67% of the mean.
+	if !ft.Weakening(0.5) || ft.Weakening(0.7) {
+		t.Errorf("Weakening is incorrect for %v.", ft)
+	}
+
+	if ft := NewFlameTrend(nil); ft.Samples != 0 || ft.Weakening(0.1) {
+		t.Errorf("FlameTrend of no samples is incorrect, got: %+v.", ft)
+	}
+}
+
+func TestFlameSamplesFromErrorLog(t *testing.T) {
+	entries := []ErrorLogEntry{
+		{Index: 0, Time: time.Now(), Data: BoilerErrorLogData{SIMFlame: 35}},
+		{Index: 1, Data: BoilerErrorLogData{SIMFlame: 35}},
+		{Index: 2, Time: time.Now(), Data: BoilerErrorLogData{SIMFlame: 0}},
+	}
+	if samples := FlameSamplesFromErrorLog(entries); len(samples) != 1 || samples[0].Signal != 35 {
+		t.Errorf("FlameSamplesFromErrorLog is incorrect, got: %+v.", samples)
+	}
+}
@@ -0,0 +1,230 @@
+package ibc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// ErrSiteLogChanged is returned when the site log shrinks while it is being read, usually because it was cleared.
+// Reading it again from the start returns the current entries.
+var ErrSiteLogChanged = errors.New("ibc: site log changed while it was being read")
+
+// SiteEvent is the type of an entry in the boiler site log.
+type SiteEvent int
+
+// Site Event Constants
+const (
+	EventPowerUp          SiteEvent = 0
+	EventSettingsChanged  SiteEvent = 1
+	EventClockSet         SiteEvent = 2
+	EventLockout          SiteEvent = 3
+	EventLockoutReset     SiteEvent = 4
+	EventWarmWeatherOff   SiteEvent = 5
+	EventWarmWeatherOn    SiteEvent = 6
+	EventNetworkChange    SiteEvent = 7
+	EventFirmwareUpdate   SiteEvent = 8
+	EventCleaningReminder SiteEvent = 9
+)
+
+var siteEventNames = [...]string{"Power Up", "Settings Changed", "Clock Set", "Lockout", "Lockout Reset", "Warm Weather Shutdown",
+	"Warm Weather Resume", "Network Change", "Firmware Update", "Cleaning Reminder"}
+
+func (e SiteEvent) String() string {
+	if !e.known() {
+		return fmt.Sprintf("Unknown (%d)", int(e))
+	}
+	return siteEventNames[e]
+}
+
+func (e SiteEvent) known() bool {
+	return e >= 0 && int(e) < len(siteEventNames)
+}
+
+// MarshalJSON encodes the SiteEvent as its name. Events this package does not recognize are encoded as numbers.
+func (e SiteEvent) MarshalJSON() ([]byte, error) {
+	if !e.known() {
+		return json.Marshal(int(e))
+	}
+	return json.Marshal(e.String())
+}
+
+// UnmarshalJSON decodes a SiteEvent from either the number reported by the boiler or the name written by MarshalJSON.
+// A JSON null leaves the SiteEvent unchanged.
+func (e *SiteEvent) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	var name string
+	if err := json.Unmarshal(data, &name); err != nil {
+		n, err := strconv.Atoi(string(data))
+		if err != nil {
+			return fmt.Errorf("ibc: invalid site event %s", data)
+		}
+		*e = SiteEvent(n)
+		return nil
+	}
+	for i, en := range siteEventNames {
+		if en == name {
+			*e = SiteEvent(i)
+			return nil
+		}
+	}
+	return fmt.Errorf("ibc: unknown site event %q", name)
+}
+
+// SiteLogData represents the data for a single entry in the site log.
+type SiteLogData struct {
+	//"rbid": 0
+	//"object_no": 23
+	//log_no:
+	// Entries is the number of entries in the site log.
+	Entries int       `json:"Entries"`
+	Time    string    `json:"Time"`
+	Date    string    `json:"Date"`
+	Event   SiteEvent `json:"Event"`
+	// Load is the 0 based load the event applies to, or -1 if it is not for a load.
+	Load int `json:"Load"`
+	// Value is event specific, ex the request number of the settings that were changed.
+	Value int `json:"Value"`
+}
+
+// SiteLogEntry represents a single entry in the boiler site log.
+type SiteLogEntry struct {
+	// Index is the number of the entry in the site log. Entries are numbered from 0.
+	Index int `json:"index"`
+	// Time is when the event occurred, according to the boiler clock. It is zero if the boiler's date could not be parsed.
+	Time  time.Time `json:"time"`
+	Event SiteEvent `json:"event"`
+	// Load is the 1 based load the event applies to, or 0 if it is not for a load.
+	Load  int `json:"load,omitempty"`
+	Value int `json:"value"`
+}
+
+func newSiteLogEntry(index int, data SiteLogData, loc *time.Location) SiteLogEntry {
+	t, _ := ParseBoilerTime(data.Date, data.Time, loc)
+	load := data.Load + 1
+	if load < 0 {
+		load = 0
+	}
+	return SiteLogEntry{Index: index, Time: t, Event: data.Event, Load: load, Value: data.Value}
+}
+
+// GetSiteLogData returns the SiteLogData for the specified logEntryNumber.
+func (b Boiler) GetSiteLogData(logEntryNumber int) (SiteLogData, error) {
+	return b.GetSiteLogDataContext(context.Background(), logEntryNumber)
+}
+
+// GetSiteLogDataContext returns the SiteLogData for the specified logEntryNumber using the provided context.
+func (b Boiler) GetSiteLogDataContext(ctx context.Context, logEntryNumber int) (SiteLogData, error) {
+	reqObj := requestObject{ObjectNum: 100, ObjectRequest: ReqSiteLogData, BoilerNum: b.BoilerNum, ObjectIndex: logEntryNumber}
+	var respObj = SiteLogData{}
+	return respObj, b.getData(ctx, reqObj, &respObj)
+}
+
+// SiteLogIterator walks a range of the boiler site log, one request per entry. It is used like ErrorLogIterator.
+type SiteLogIterator struct {
+	b     Boiler
+	next  int
+	end   int
+	entry SiteLogEntry
+	err   error
+}
+
+// SiteLogIterator returns an iterator over the site log entries from start up to, but not including, end.
+func (b Boiler) SiteLogIterator(start int, end int) *SiteLogIterator {
+	return &SiteLogIterator{b: b, next: start, end: end}
+}
+
+// Next fetches the next entry in the range. It returns false when the range is exhausted or an error occurs.
+// If the log shrinks while it is being read, Err returns an error matching ErrSiteLogChanged.
+func (it *SiteLogIterator) Next(ctx context.Context) bool {
+	if it.err != nil || it.next >= it.end {
+		return false
+	}
+	data, err := it.b.GetSiteLogDataContext(ctx, it.next)
+	if err != nil {
+		it.err = err
+		return false
+	}
+	// The log no longer holds this entry, so the entries already read may not be the ones that follow.
+	if it.next >= data.Entries {
+		it.err = fmt.Errorf("%w: entry %d requested but the log holds %d", ErrSiteLogChanged, it.next, data.Entries)
+		return false
+	}
+	it.entry = newSiteLogEntry(it.next, data, it.b.location())
+	it.next++
+	return true
+}
+
+// Entry returns the entry fetched by the last call to Next.
+func (it *SiteLogIterator) Entry() SiteLogEntry {
+	return it.entry
+}
+
+// Err returns the error, if any, that stopped the iteration.
+func (it *SiteLogIterator) Err() error {
+	return it.err
+}
+
+// SiteLogLen returns the number of entries in the site log.
+func (b Boiler) SiteLogLen() (int, error) {
+	return b.SiteLogLenContext(context.Background())
+}
+
+// SiteLogLenContext returns the number of entries in the site log using the provided context. The boiler answers
+// an empty log with an empty object, which is reported as 0 entries.
+func (b Boiler) SiteLogLenContext(ctx context.Context) (int, error) {
+	data, err := b.GetSiteLogDataContext(ctx, 0)
+	return data.Entries, err
+}
+
+// SiteLog returns every entry in the boiler site log.
+func (b Boiler) SiteLog() ([]SiteLogEntry, error) {
+	return b.SiteLogContext(context.Background())
+}
+
+// SiteLogContext returns every entry in the boiler site log using the provided context.
+func (b Boiler) SiteLogContext(ctx context.Context) ([]SiteLogEntry, error) {
+	entries, _, err := b.SiteLogSinceContext(ctx, 0)
+	return entries, err
+}
+
+// SiteLogRange returns the site log entries from start up to, but not including, end.
+func (b Boiler) SiteLogRange(start int, end int) ([]SiteLogEntry, error) {
+	return b.SiteLogRangeContext(context.Background(), start, end)
+}
+
+// SiteLogRangeContext returns the site log entries from start up to, but not including, end using the provided context.
+// The entries read before an error occurs are returned along with the error.
+func (b Boiler) SiteLogRangeContext(ctx context.Context, start int, end int) ([]SiteLogEntry, error) {
+	entries := make([]SiteLogEntry, 0)
+	it := b.SiteLogIterator(start, end)
+	for it.Next(ctx) {
+		entries = append(entries, it.Entry())
+	}
+	return entries, it.Err()
+}
+
+// SiteLogSince returns the site log entries starting at index, along with the index to pass on the next call.
+func (b Boiler) SiteLogSince(index int) ([]SiteLogEntry, int, error) {
+	return b.SiteLogSinceContext(context.Background(), index)
+}
+
+// SiteLogSinceContext returns the site log entries starting at index, along with the index to pass on the next call,
+// using the provided context. If the log now holds fewer than index entries it has been cleared, and it is read from the start.
+// If it is cleared during the read, the entries read so far are returned with an error matching ErrSiteLogChanged.
+func (b Boiler) SiteLogSinceContext(ctx context.Context, index int) ([]SiteLogEntry, int, error) {
+	n, err := b.SiteLogLenContext(ctx)
+	if err != nil {
+		return nil, index, err
+	}
+	if index > n || index < 0 {
+		index = 0
+	}
+	entries, err := b.SiteLogRangeContext(ctx, index, n)
+	return entries, index + len(entries), err
+}
@@ -0,0 +1,117 @@
+package ibc
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+// maxTestSiteLog is the largest site log returned by siteLogObjects.
+const maxTestSiteLog = 8
+
+// siteLogObjects returns a site log of n entries, one hour apart, alternating between settings changes to load 2
+// and power ups. Entries past the end of the log are empty objects, as the boiler answers an empty log.
+func siteLogObjects(n int) []fakeObject {
+	objects := make([]fakeObject, maxTestSiteLog)
+	for i := range objects {
+		objects[i] = fakeObject{ReqSiteLogData, 0, i, `{}`}
+		if i >= n {
+			continue
+		}
+		event, load := EventSettingsChanged, 1
+		if i%2 == 1 {
+			event, load = EventPowerUp, -1
+		}
+		objects[i].body = fmt.Sprintf(`{"rbid":0,"object_no":23,"Entries":%d,"Date":"03/14/19","Time":"%02d:00","Event":%d,"Load":%d,"Value":16}`,
+			n, i, event, load)
+	}
+	return objects
+}
+
+func TestSiteLog(t *testing.T) {
+	b, fb, done := newFakeBoiler(siteLogObjects(3)...)
+	defer done()
+	b.Location = time.UTC
+
+	entries, err := b.SiteLog()
+	if err != nil {
+		t.Fatalf("SiteLog returned error: %v", err)
+	}
+	if len(entries) != 3 {
+		t.Fatalf("SiteLog length is incorrect, got: %d, want: %d.", len(entries), 3)
+	}
+	want := SiteLogEntry{Index: 2, Time: time.Date(2019, time.March, 14, 2, 0, 0, 0, time.UTC), Event: EventSettingsChanged, Load: 2, Value: 16}
+	if entries[2] != want {
+		t.Errorf("SiteLog entry is incorrect, got: %+v, want: %+v.", entries[2], want)
+	}
+	if entries[1].Event != EventPowerUp || entries[1].Load != 0 {
+		t.Errorf("SiteLog power up entry is incorrect, got: %+v.", entries[1])
+	}
+
+	fb.setAll(siteLogObjects(0)...)
+	if n, err := b.SiteLogLen(); err != nil || n != 0 {
+		t.Errorf("SiteLogLen of an empty log is incorrect, got: %d %v, want: 0.", n, err)
+	}
+	if entries, err := b.SiteLog(); err != nil || len(entries) != 0 {
+		t.Errorf("SiteLog of an empty log is incorrect, got: %v %v.", entries, err)
+	}
+}
+
+func TestSiteLogCleared(t *testing.T) {
+	b, fb, done := newFakeBoiler(siteLogObjects(4)...)
+	defer done()
+	b.Location = time.UTC
+	fb.onRead = func(req requestObject) {
+		if req.ObjectIndex == 1 {
+			fb.setAll(siteLogObjects(1)...)
+		}
+	}
+
+	entries, err := b.SiteLog()
+	if !errors.Is(err, ErrSiteLogChanged) || len(entries) != 2 {
+		t.Errorf("SiteLog cleared while reading is incorrect, got: %d entries, %v, want: 2 entries, %v.", len(entries), err, ErrSiteLogChanged)
+	}
+}
+
+func TestSiteLogSince(t *testing.T) {
+	b, fb, done := newFakeBoiler(siteLogObjects(2)...)
+	defer done()
+	b.Location = time.UTC
+
+	entries, next, err := b.SiteLogSince(0)
+	if err != nil || len(entries) != 2 || next != 2 {
+		t.Fatalf("SiteLogSince(0) is incorrect, got: %d entries, next %d, %v.", len(entries), next, err)
+	}
+	fb.setAll(siteLogObjects(5)...)
+	entries, next, err = b.SiteLogSince(next)
+	if err != nil || len(entries) != 3 || entries[0].Index != 2 || next != 5 {
+		t.Errorf("SiteLogSince(2) is incorrect, got: %d entries, next %d, %v.", len(entries), next, err)
+	}
+	fb.setAll(siteLogObjects(1)...)
+	entries, next, err = b.SiteLogSince(next)
+	if err != nil || len(entries) != 1 || next != 1 {
+		t.Errorf("SiteLogSince after the log was cleared is incorrect, got: %d entries, next %d, %v.", len(entries), next, err)
+	}
+}
+
+func TestSiteEventJSON(t *testing.T) {
+	for _, e := range []SiteEvent{EventLockout, SiteEvent(42)} {
+		data, err := json.Marshal(e)
+		if err != nil {
+			t.Fatalf("Marshal returned error: %v", err)
+		}
+		var got SiteEvent
+		if err := json.Unmarshal(data, &got); err != nil || got != e {
+			t.Errorf("SiteEvent JSON round trip is incorrect, got: %v %v from %s, want: %v.", got, err, data, e)
+		}
+	}
+	if data, _ := json.Marshal(EventLockout); string(data) != `"Lockout"` {
+		t.Errorf("SiteEvent JSON is incorrect, got: %s, want: %s.", data, `"Lockout"`)
+	}
+	got := EventLockout
+	if err := json.Unmarshal([]byte(`null`), &got); err != nil || got != EventLockout {
+		t.Errorf("Unmarshal of null is incorrect, got: %v %v, want: %v.", got, err, EventLockout)
+	}
+}
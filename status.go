@@ -0,0 +1,76 @@
+package ibc
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+)
+
+// Status is the operating status of the boiler, as reported in BoilerData and BoilerStatusData.
+type Status int
+
+// System Status Constants
+const (
+	Standby      Status = 0
+	Purging      Status = 1
+	Igniting     Status = 2
+	Heating      Status = 3
+	Circulating  Status = 4
+	Error        Status = 5
+	Initializing Status = 6
+)
+
+var statusNames = [...]string{"Standby", "Purging", "Igniting", "Heating", "Circulating", "Error", "Initializing"}
+
+func (s Status) known() bool {
+	return s >= 0 && int(s) < len(statusNames)
+}
+
+func (s Status) String() string {
+	if !s.known() {
+		return fmt.Sprintf("Unknown (%d)", int(s))
+	}
+	return statusNames[s]
+}
+
+// IsFault returns true if the boiler is in the Error state, or reports a status this package does not recognize.
+func (s Status) IsFault() bool {
+	return s == Error || !s.known()
+}
+
+// IsFiring returns true while the burner is lighting or lit.
+func (s Status) IsFiring() bool {
+	return s == Igniting || s == Heating
+}
+
+// MarshalJSON encodes the Status as its name. Statuses this package does not recognize are encoded as numbers.
+func (s Status) MarshalJSON() ([]byte, error) {
+	if !s.known() {
+		return json.Marshal(int(s))
+	}
+	return json.Marshal(s.String())
+}
+
+// UnmarshalJSON decodes a Status from either the number reported by the boiler or the name written by MarshalJSON.
+// A JSON null leaves the Status unchanged.
+func (s *Status) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	var name string
+	if err := json.Unmarshal(data, &name); err != nil {
+		n, err := strconv.Atoi(string(data))
+		if err != nil {
+			return fmt.Errorf("ibc: invalid status %s", data)
+		}
+		*s = Status(n)
+		return nil
+	}
+	for i, sn := range statusNames {
+		if sn == name {
+			*s = Status(i)
+			return nil
+		}
+	}
+	return fmt.Errorf("ibc: unknown status %q", name)
+}
@@ -0,0 +1,59 @@
+package ibc
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestStatus(t *testing.T) {
+	tests := []struct {
+		status            Status
+		name              string
+		isFault, isFiring bool
+	}{
+		{Standby, "Standby", false, false},
+		{Igniting, "Igniting", false, true},
+		{Heating, "Heating", false, true},
+		{Circulating, "Circulating", false, false},
+		{Error, "Error", true, false},
+		{Status(9), "Unknown (9)", true, false},
+	}
+	for _, tt := range tests {
+		if tt.status.String() != tt.name || tt.status.IsFault() != tt.isFault || tt.status.IsFiring() != tt.isFiring {
+			t.Errorf("Status %d is incorrect, got: %s %v %v, want: %s %v %v.", int(tt.status),
+				tt.status, tt.status.IsFault(), tt.status.IsFiring(), tt.name, tt.isFault, tt.isFiring)
+		}
+	}
+}
+
+func TestStatusJSON(t *testing.T) {
+	var bd BoilerData
+	if err := json.Unmarshal([]byte(`{"status":3}`), &bd); err != nil {
+		t.Fatal(err)
+	}
+	if bd.Status != Heating {
+		t.Errorf("Status is incorrect, got: %v, want: %v.", bd.Status, Heating)
+	}
+
+	b, err := json.Marshal(BoilerStatusData{Status: Error})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var bsd BoilerStatusData
+	if err := json.Unmarshal(b, &bsd); err != nil || bsd.Status != Error {
+		t.Errorf("Status round trip is incorrect, got: %v %v from %s, want: %v.", bsd.Status, err, b, Error)
+	}
+
+	b, _ = json.Marshal(Status(9))
+	if string(b) != "9" {
+		t.Errorf("Unknown Status is incorrect, got: %s, want: 9.", b)
+	}
+	var s Status
+	if err := json.Unmarshal([]byte(`"Boiling"`), &s); err == nil {
+		t.Error("Unmarshal returned no error for an unknown status name.")
+	}
+	s = Heating
+	if err := json.Unmarshal([]byte(`null`), &s); err != nil || s != Heating {
+		t.Errorf("Unmarshal of null is incorrect, got: %v %v, want: %v.", s, err, Heating)
+	}
+}
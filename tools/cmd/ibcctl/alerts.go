@@ -0,0 +1,75 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/alecthomas/template"
+	"github.com/ericdaugherty/ibc"
+)
+
+var alertsTemplateConsole = `Alerts:        {{if .ad.Enabled}}Enabled{{else}}Disabled{{end}}
+Contacts:      {{range $index, $element := .ad.Contacts}}{{if $index}}, {{end}}{{$element}}{{end}}
+Sender:        {{.ad.From}}
+SMTP Server:   {{.ad.SMTPServer}}:{{.ad.SMTPPort}}
+Alert On:      {{.ad.AlertedConditions}}
+Active Alerts: {{.ad.ActiveAlerts}}
+{{if .recent}}Recent Alerts:
+{{end}}{{range .recent}}{{.Time.Format "2006-01-02 15:04"}}  {{.Condition}}{{if not .Sent}} (not sent){{end}}
+{{end}}`
+
+type alertsCommand struct {
+	Show  alertsShowCommand  `command:"show" description:"Show the boiler's alert settings and recent alerts."`
+	Check alertsCheckCommand `command:"check" description:"Check that the boiler can deliver its alerts to the expected contacts."`
+}
+
+type alertsShowCommand struct{}
+
+func (c *alertsShowCommand) Execute(args []string) error {
+	b, err := boiler()
+	if err != nil {
+		return err
+	}
+	ad, err := b.GetAlertData()
+	if err != nil {
+		return err
+	}
+	return showAlerts(b, ad)
+}
+
+func showAlerts(b ibc.Boiler, ad ibc.BoilerAlertData) error {
+	tmplOpts := make(map[string]interface{})
+	tmplOpts["ad"] = ad
+	tmplOpts["recent"] = ad.RecentAlerts(b.Location)
+	tmpl := template.Must(template.New("").Parse(alertsTemplateConsole))
+	return tmpl.Execute(os.Stdout, tmplOpts)
+}
+
+type alertsCheckCommand struct {
+	Expect []string `short:"e" long:"expect" description:"An e-mail address the boiler should send alerts to. Repeat for each contact, ex -e service@example.com -e owner@example.com"`
+}
+
+func (c *alertsCheckCommand) Execute(args []string) error {
+	b, err := boiler()
+	if err != nil {
+		return err
+	}
+	ad, err := b.GetAlertData()
+	if err != nil {
+		return err
+	}
+	if err := showAlerts(b, ad); err != nil {
+		return err
+	}
+
+	problems := ad.CheckContacts(c.Expect...)
+	if len(problems) == 0 {
+		fmt.Println("\nAlert settings OK.")
+		return nil
+	}
+	fmt.Println("\nProblems:")
+	for _, p := range problems {
+		fmt.Println(" -", p)
+	}
+	return fmt.Errorf("found %d problems with the alert settings", len(problems))
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+
+	"github.com/ericdaugherty/ibc"
+)
+
+type captureCommand struct {
+	File string `short:"o" long:"out" description:"The CSV file to write, ex -o capture.csv. Defaults to stdout."`
+}
+
+func (c *captureCommand) Execute(args []string) error {
+	b, err := boiler()
+	if err != nil {
+		return err
+	}
+	bd, err := b.GetBoilerData()
+	if err != nil {
+		return err
+	}
+	capture, err := b.Capture()
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "%s capture taken %s, %d samples every %v\n", capture.Trigger, formatLogTime(capture.Time, "2006-01-02 15:04:05"),
+		len(capture.Samples), capture.Interval)
+
+	if c.File == "" {
+		return writeCaptureCSV(os.Stdout, capture, bd.Units())
+	}
+	f, err := os.Create(c.File)
+	if err != nil {
+		return err
+	}
+	if err := writeCaptureCSV(f, capture, bd.Units()); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// writeCaptureCSV writes one row per sample, with temperatures in the specified units.
+func writeCaptureCSV(out io.Writer, capture ibc.Capture, u ibc.Units) error {
+	unit, temp := "C", ibc.Temperature.C
+	if u == ibc.Imperial {
+		unit, temp = "F", ibc.Temperature.F
+	}
+	formatTemp := func(t ibc.Temperature) string {
+		return strconv.FormatFloat(temp(t), 'f', 1, 64)
+	}
+
+	w := csv.NewWriter(out)
+	w.Write([]string{"seconds", "opStatus", "fanRPM", "heatOut", "supply" + unit, "return" + unit, "stack" + unit, "flameSense"})
+	for _, s := range capture.Samples {
+		w.Write([]string{
+			strconv.FormatFloat(s.Offset.Seconds(), 'f', -1, 64),
+			s.OpStatus.String(),
+			strconv.Itoa(s.FanRPM),
+			strconv.Itoa(s.HeatOut),
+			formatTemp(s.SupplyTemp),
+			formatTemp(s.ReturnTemp),
+			formatTemp(s.StackTemp),
+			strconv.Itoa(s.FlameSense),
+		})
+	}
+	w.Flush()
+	return w.Error()
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ericdaugherty/ibc"
+)
+
+type clockCommand struct {
+	Show clockShowCommand `command:"show" description:"Show the boiler clock and its drift from this host."`
+	Sync clockSyncCommand `command:"sync" description:"Set the boiler clock to the time on this host."`
+}
+
+type clockShowCommand struct{}
+
+func (c *clockShowCommand) Execute(args []string) error {
+	b, err := boiler()
+	if err != nil {
+		return err
+	}
+	drift, err := b.CheckClock()
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Boiler Clock: %s\n", drift.Boiler.Format(time.RFC1123))
+	fmt.Printf("Host Clock:   %s\n", drift.Host.Format(time.RFC1123))
+	fmt.Printf("Drift:        %s\n", drift.Drift.Round(time.Second))
+	return nil
+}
+
+type clockSyncCommand struct {
+	MaxDrift int  `long:"ifDriftSeconds" description:"Only set the clock if it differs from this host by more than this many seconds." default:"0"`
+	DryRun   bool `long:"dryRun" description:"Show the request that would be sent without changing the boiler."`
+}
+
+func (c *clockSyncCommand) Execute(args []string) error {
+	b, err := boiler()
+	if err != nil {
+		return err
+	}
+	drift, err := b.CheckClock()
+	if errors.Is(err, ibc.ErrClockNotSet) {
+		// An unset clock can not be compared, but can still be set.
+		fmt.Println("Boiler clock is not set.")
+	} else if err != nil {
+		return err
+	} else {
+		fmt.Printf("Boiler clock is off by %s.\n", drift.Drift.Round(time.Second))
+		if !drift.Exceeds(time.Duration(c.MaxDrift) * time.Second) {
+			fmt.Println("Boiler clock not changed.")
+			return nil
+		}
+	}
+
+	b.AllowWrites = true
+	b.DryRun = c.DryRun
+	now := time.Now()
+	res, err := b.SetClock(now)
+	if err != nil {
+		return err
+	}
+	printWriteResult(res)
+	if res.Verified {
+		fmt.Printf("Boiler clock set to %s.\n", now.In(b.Location).Format(time.RFC1123))
+	}
+	return nil
+}
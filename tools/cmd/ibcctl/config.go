@@ -0,0 +1,156 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/ericdaugherty/ibc"
+)
+
+type configCommand struct {
+	Export configExportCommand `command:"export" description:"Export every settings object to a JSON file."`
+	Import configImportCommand `command:"import" description:"Restore settings from a JSON file written by export."`
+	Diff   configDiffCommand   `command:"diff" description:"Compare the settings of two boilers or saved configurations."`
+}
+
+type configExportCommand struct {
+	File string `short:"o" long:"out" description:"The file to write, ex -o boiler.json. Defaults to stdout."`
+}
+
+func (c *configExportCommand) Execute(args []string) error {
+	b, err := boiler()
+	if err != nil {
+		return err
+	}
+	cfg, err := b.ExportConfig()
+	if err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(cfg, "", "  ")
+	if err != nil {
+		return err
+	}
+	data = append(data, '\n')
+	if c.File == "" {
+		_, err = os.Stdout.Write(data)
+		return err
+	}
+	return ioutil.WriteFile(c.File, data, 0644)
+}
+
+type configImportCommand struct {
+	File   string `short:"f" long:"file" description:"The JSON file written by export, ex -f boiler.json" required:"true"`
+	Yes    bool   `short:"y" long:"yes" description:"Apply the changes without asking for confirmation."`
+	DryRun bool   `long:"dryRun" description:"Show the requests that would be sent without changing the boiler."`
+}
+
+func (c *configImportCommand) Execute(args []string) error {
+	cfg, err := readConfig(c.File)
+	if err != nil {
+		return err
+	}
+
+	b, err := boiler()
+	if err != nil {
+		return err
+	}
+	b.AllowWrites = true
+	b.DryRun = c.DryRun
+
+	changed := false
+	results, err := b.ImportConfig(cfg, func(diff ibc.ConfigDiff) bool {
+		changed = true
+		fmt.Println(diff.Format(cfg.Units))
+		return c.Yes || c.DryRun || confirm(fmt.Sprintf("Apply %d changes?", len(diff)))
+	})
+	for _, res := range results {
+		printWriteResult(res)
+	}
+	if err != nil {
+		return err
+	}
+	if !changed {
+		fmt.Println("The boiler configuration already matches", c.File)
+	} else if !c.DryRun {
+		fmt.Println("Configuration restored and verified.")
+	}
+	return nil
+}
+
+// readConfig reads a configuration written by config export.
+func readConfig(file string) (ibc.Config, error) {
+	var cfg ibc.Config
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		return cfg, err
+	}
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return cfg, fmt.Errorf("unable to parse %s: %v", file, err)
+	}
+	return cfg, nil
+}
+
+// confirm asks a yes or no question on the console and returns true if the answer is yes.
+func confirm(question string) bool {
+	fmt.Printf("%s [y/N] ", question)
+	answer, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	return answer == "y" || answer == "yes"
+}
+
+type configDiffCommand struct {
+	Args struct {
+		From string `positional-arg-name:"FROM" description:"A boiler URL or a file written by export." required:"true"`
+		To   string `positional-arg-name:"TO" description:"A boiler URL or a file written by export. Defaults to the boiler given by -u."`
+	} `positional-args:"yes"`
+}
+
+func (c *configDiffCommand) Execute(args []string) error {
+	from, err := loadConfig(c.Args.From)
+	if err != nil {
+		return err
+	}
+	to, err := loadConfig(c.Args.To)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("--- %s\n+++ %s\n", describeConfig(c.Args.From, from), describeConfig(c.Args.To, to))
+	diff := ibc.DiffConfig(from, to)
+	if len(diff) == 0 {
+		fmt.Println("No differences.")
+		return nil
+	}
+	fmt.Println(diff.Format(from.Units))
+	return nil
+}
+
+// loadConfig reads a configuration from a boiler URL or a file written by config export. An empty source is
+// the boiler given by the -u option.
+func loadConfig(source string) (ibc.Config, error) {
+	var b ibc.Boiler
+	var err error
+	switch {
+	case source == "":
+		b, err = boiler()
+	case strings.HasPrefix(source, "http://") || strings.HasPrefix(source, "https://"):
+		b, err = boilerAt(source)
+	default:
+		return readConfig(source)
+	}
+	if err != nil {
+		return ibc.Config{}, err
+	}
+	return b.ExportConfig()
+}
+
+func describeConfig(source string, cfg ibc.Config) string {
+	if source == "" {
+		source = opts.BoilerURL
+	}
+	return fmt.Sprintf("%s (%s, firmware %s, %s)", source, cfg.Model, cfg.FirmwareVersion, cfg.Exported.Format("2006-01-02 15:04"))
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/ericdaugherty/ibc"
+)
+
+// newConfigServer starts a server that answers every settings object with setPoint as its SetPoint.
+func newConfigServer(setPoint int) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Request int `json:"object_request"`
+		}
+		json.Unmarshal([]byte(r.URL.Query().Get("json")), &req)
+		if req.Request == ibc.ReqBoilerData {
+			fmt.Fprint(w, `{"rbid":0,"object_no":11,"model":"SL 20-115 G3","fwversion":"2.10","imperial":1}`)
+			return
+		}
+		fmt.Fprintf(w, `{"rbid":0,"object_no":%d,"SetPoint":%d}`, req.Request, setPoint)
+	}))
+}
+
+func TestConfigDiffURLs(t *testing.T) {
+	from, to := newConfigServer(320), newConfigServer(329)
+	defer from.Close()
+	defer to.Close()
+
+	// Neither boiler is given with -u.
+	opts.BoilerURL = ""
+	stdout := os.Stdout
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	os.Stdout = w
+	c := &configDiffCommand{}
+	c.Args.From, c.Args.To = from.URL+"/", to.URL+"/"
+	err = c.Execute(nil)
+	os.Stdout = stdout
+	w.Close()
+	out, _ := ioutil.ReadAll(r)
+
+	if err != nil {
+		t.Fatalf("config diff returned error: %v", err)
+	}
+	if want := "Load Settings (load 2) SetPoint: 176°F -> 180°F"; !strings.Contains(string(out), want) {
+		t.Errorf("config diff output is incorrect, got: %s, want: %s.", out, want)
+	}
+}
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/ericdaugherty/ibc"
+	flags "github.com/jessevdk/go-flags"
+)
+
+var opts struct {
+	BoilerURL string `short:"u" long:"url" description:"URL of the Boiler, ex -u \"http://192.168.10.2/\""`
+	BoilerNum int    `short:"b" long:"boiler" description:"The number of the boiler on a cascade network." default:"0"`
+	TimeZone  string `long:"tz" description:"The time zone the boiler clock is set to, ex --tz \"America/Denver\". Defaults to the local time zone."`
+	Timeout   int    `long:"timeout" description:"The number of seconds to wait for the boiler to respond to each request." default:"30"`
+}
+var parser = flags.NewParser(&opts, flags.Default)
+
+func main() {
+
+	parser.AddCommand("alerts", "Show or check alert settings", "Show or check the boiler's own alert settings and recent alerts.", &alertsCommand{})
+	parser.AddCommand("capture", "Export the capture buffer", "Export the boiler capture buffer, a snapshot of the last firing sequence, as CSV.", &captureCommand{})
+	parser.AddCommand("clock", "Read or set the boiler clock", "Read or set the boiler clock.", &clockCommand{})
+	parser.AddCommand("config", "Back up or restore settings", "Back up or restore every settings object of the boiler.", &configCommand{})
+	parser.AddCommand("load", "Read or change load settings", "Read or change the settings of a load.", &loadCommand{})
+	parser.AddCommand("setback", "Read or apply setback schedules", "Read or apply the weekly setback schedules of the loads.", &setbackCommand{})
+	parser.AddCommand("sitelog", "Show the site log", "Show the boiler site log, the events recorded separately from the error log.", &siteLogCommand{})
+	parser.AddCommand("versions", "Show firmware versions", "Show the firmware and board versions of the boiler.", &versionsCommand{})
+
+	// Parse command line flags and run the command.
+	if _, err := parser.Parse(); err != nil {
+		if flagsErr, ok := err.(*flags.Error); ok && flagsErr.Type == flags.ErrHelp {
+			os.Exit(0)
+		} else {
+			os.Exit(1)
+		}
+	}
+}
+
+// boiler returns the Boiler selected by the command line options.
+func boiler() (ibc.Boiler, error) {
+	if opts.BoilerURL == "" {
+		return ibc.Boiler{}, errors.New("the boiler URL is required, ex -u \"http://192.168.10.2/\"")
+	}
+	return boilerAt(opts.BoilerURL)
+}
+
+// boilerAt returns the Boiler at url, using the boiler number, time zone and timeout from the command line options.
+func boilerAt(url string) (ibc.Boiler, error) {
+	b := ibc.Boiler{BaseURL: url, BoilerNum: opts.BoilerNum, Timeout: time.Duration(opts.Timeout) * time.Second, Location: time.Local}
+	if opts.TimeZone != "" {
+		loc, err := time.LoadLocation(opts.TimeZone)
+		if err != nil {
+			return b, err
+		}
+		b.Location = loc
+	}
+	return b, nil
+}
+
+// printWriteResult shows the request made to the boiler for a write.
+func printWriteResult(res ibc.WriteResult) {
+	if !res.Sent {
+		fmt.Println("Dry run, request not sent:")
+		fmt.Println(res.Object)
+		return
+	}
+	fmt.Println("Sent:", res.Object)
+}
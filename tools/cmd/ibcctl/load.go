@@ -0,0 +1,119 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/alecthomas/template"
+	"github.com/ericdaugherty/ibc"
+)
+
+var loadSettingsTemplateConsole = `Load Number:      {{.lsd.LoadNumber}}
+Load Type:        {{.lsd.LoadTypeName}}
+Priority:         {{.lsd.Priority}}
+Set Point:        {{.lsd.SetPoint.Format .units}}
+Design Supply:    {{.lsd.DesignSupplyTemp.Format .units}}
+Design Outdoor:   {{.lsd.DesignOutdoorTemp.Format .units}}
+Design Indoor:    {{.lsd.DesignIndoorTemp.Format .units}}
+Min Supply:       {{.lsd.MinSupplyTemp.Format .units}}
+Max Supply:       {{.lsd.MaxSupplyTemp.Format .units}}
+Differential:     {{.lsd.Differential.Format .units}}
+Warm Weather Off: {{.lsd.WarmWeatherShutdown.Format .units}}
+`
+
+type loadCommand struct {
+	Show loadShowCommand `command:"show" description:"Show the settings of a load."`
+	Set  loadSetCommand  `command:"set" description:"Change the settings of a load."`
+}
+
+type loadShowCommand struct {
+	Load int `short:"l" long:"load" description:"The load number, 1 through 4." required:"true"`
+}
+
+func (c *loadShowCommand) Execute(args []string) error {
+	b, err := boiler()
+	if err != nil {
+		return err
+	}
+	return showLoadSettings(b, c.Load)
+}
+
+func showLoadSettings(b ibc.Boiler, loadNum int) error {
+	bd, err := b.GetBoilerData()
+	if err != nil {
+		return err
+	}
+	lsd, err := b.GetLoadSettingsData(loadNum)
+	if err != nil {
+		return err
+	}
+
+	tmplOpts := make(map[string]interface{})
+	tmplOpts["lsd"] = lsd
+	tmplOpts["units"] = bd.Units()
+	tmpl := template.Must(template.New("").Parse(loadSettingsTemplateConsole))
+	return tmpl.Execute(os.Stdout, tmplOpts)
+}
+
+type loadSetCommand struct {
+	Load          int    `short:"l" long:"load" description:"The load number, 1 through 4." required:"true"`
+	SetPoint      string `long:"setPoint" description:"The target supply temperature, ex --setPoint 180F"`
+	Priority      int    `long:"priority" description:"The load priority, 1 (highest) through 4."`
+	DesignSupply  string `long:"designSupply" description:"The design supply temperature, ex --designSupply 82C"`
+	DesignOutdoor string `long:"designOutdoor" description:"The design outdoor temperature, ex --designOutdoor=-10F"`
+	DesignIndoor  string `long:"designIndoor" description:"The design indoor temperature, ex --designIndoor 70F"`
+	DryRun        bool   `long:"dryRun" description:"Show the request that would be sent without changing the boiler."`
+}
+
+func (c *loadSetCommand) Execute(args []string) error {
+	b, err := boiler()
+	if err != nil {
+		return err
+	}
+	b.AllowWrites = true
+	b.DryRun = c.DryRun
+
+	lsd, err := b.GetLoadSettingsData(c.Load)
+	if err != nil {
+		return err
+	}
+
+	temps := []struct {
+		value string
+		name  string
+		field *ibc.Temperature
+	}{
+		{c.SetPoint, "SetPoint", &lsd.SetPoint},
+		{c.DesignSupply, "DesignSupplyTemp", &lsd.DesignSupplyTemp},
+		{c.DesignOutdoor, "DesignOutdoorTemp", &lsd.DesignOutdoorTemp},
+		{c.DesignIndoor, "DesignIndoorTemp", &lsd.DesignIndoorTemp},
+	}
+	for _, t := range temps {
+		if t.value == "" {
+			continue
+		}
+		if !lsd.Uses(t.name) {
+			return fmt.Errorf("load %d is a %s load, which does not use %s", c.Load, lsd.LoadTypeName(), t.name)
+		}
+		if *t.field, err = ibc.ParseTemperature(t.value); err != nil {
+			return err
+		}
+	}
+	if c.Priority != 0 {
+		if !lsd.Uses("Priority") {
+			return fmt.Errorf("load %d is a %s load, which does not use Priority", c.Load, lsd.LoadTypeName())
+		}
+		lsd.Priority = c.Priority
+	}
+
+	res, err := b.SetLoadSettings(lsd)
+	if err != nil {
+		return err
+	}
+	printWriteResult(res)
+	if res.Verified {
+		fmt.Println("Load settings changed and verified.")
+		return showLoadSettings(b, c.Load)
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+
+	"github.com/ericdaugherty/ibc"
+	yaml "gopkg.in/yaml.v2"
+)
+
+type setbackCommand struct {
+	Show  setbackShowCommand  `command:"show" description:"Show the setback schedule of a load."`
+	Apply setbackApplyCommand `command:"apply" description:"Apply setback schedules from a YAML file."`
+}
+
+type setbackShowCommand struct {
+	Load int `short:"l" long:"load" description:"The load number, 1 through 4." required:"true"`
+}
+
+func (c *setbackShowCommand) Execute(args []string) error {
+	b, err := boiler()
+	if err != nil {
+		return err
+	}
+	return showSetbackSchedule(b, c.Load)
+}
+
+func showSetbackSchedule(b ibc.Boiler, loadNum int) error {
+	bd, err := b.GetBoilerData()
+	if err != nil {
+		return err
+	}
+	s, err := b.GetSetbackSchedule(loadNum)
+	if err != nil {
+		return err
+	}
+	fmt.Print(s.Format(bd.Units()))
+	return nil
+}
+
+type setbackApplyCommand struct {
+	File   string `short:"f" long:"file" description:"The YAML schedule file, ex -f setback.yaml" required:"true"`
+	DryRun bool   `long:"dryRun" description:"Show the requests that would be sent without changing the boiler."`
+}
+
+func (c *setbackApplyCommand) Execute(args []string) error {
+	data, err := ioutil.ReadFile(c.File)
+	if err != nil {
+		return err
+	}
+	var plan ibc.SetbackPlan
+	if err := yaml.UnmarshalStrict(data, &plan); err != nil {
+		return fmt.Errorf("unable to parse %s: %v", c.File, err)
+	}
+
+	b, err := boiler()
+	if err != nil {
+		return err
+	}
+	b.AllowWrites = true
+	b.DryRun = c.DryRun
+
+	results, err := b.ApplySetbackPlan(plan)
+	for _, res := range results {
+		printWriteResult(res)
+	}
+	if err != nil {
+		return err
+	}
+	if c.DryRun {
+		return nil
+	}
+	fmt.Println("Setback schedules changed and verified.")
+	for _, pl := range plan.Loads {
+		if err := showSetbackSchedule(b, pl.Load); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"github.com/ericdaugherty/ibc"
+)
+
+type siteLogCommand struct {
+	Format string `short:"f" long:"format" description:"The output format." choice:"table" choice:"json" choice:"csv" default:"table"`
+	Last   int    `short:"n" long:"last" description:"Only show the last n entries. Shows every entry by default."`
+}
+
+func (c *siteLogCommand) Execute(args []string) error {
+	b, err := boiler()
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	n, err := b.SiteLogLenContext(ctx)
+	if err != nil {
+		return err
+	}
+	start := 0
+	if c.Last > 0 && c.Last < n {
+		start = n - c.Last
+	}
+	entries, err := b.SiteLogRangeContext(ctx, start, n)
+	if err != nil {
+		return err
+	}
+
+	switch c.Format {
+	case "json":
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(entries)
+	case "csv":
+		return writeSiteLogCSV(entries)
+	}
+	return writeSiteLogTable(entries)
+}
+
+func writeSiteLogTable(entries []ibc.SiteLogEntry) error {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "#\tTime\tEvent\tLoad\tValue")
+	for _, e := range entries {
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%d\n", e.Index, formatLogTime(e.Time, "2006-01-02 15:04"), e.Event, formatLoad(e.Load), e.Value)
+	}
+	return w.Flush()
+}
+
+func writeSiteLogCSV(entries []ibc.SiteLogEntry) error {
+	w := csv.NewWriter(os.Stdout)
+	w.Write([]string{"index", "time", "event", "load", "value"})
+	for _, e := range entries {
+		w.Write([]string{strconv.Itoa(e.Index), formatLogTime(e.Time, time.RFC3339), e.Event.String(), formatLoad(e.Load), strconv.Itoa(e.Value)})
+	}
+	w.Flush()
+	return w.Error()
+}
+
+// formatLogTime formats a log entry time, leaving it blank if the boiler's date could not be parsed.
+func formatLogTime(t time.Time, layout string) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(layout)
+}
+
+func formatLoad(load int) string {
+	if load == 0 {
+		return ""
+	}
+	return strconv.Itoa(load)
+}
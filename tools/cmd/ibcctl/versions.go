@@ -0,0 +1,70 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"text/tabwriter"
+
+	"github.com/ericdaugherty/ibc"
+)
+
+type versionsCommand struct {
+	All          bool `short:"a" long:"all" description:"Show the versions of every boiler on the cascade network."`
+	Capabilities bool `short:"c" long:"capabilities" description:"Also list the requests each boiler's firmware does not support."`
+}
+
+func (c *versionsCommand) Execute(args []string) error {
+	b, err := boiler()
+	if err != nil {
+		return err
+	}
+	units := []ibc.Boiler{b}
+	if c.All {
+		if units, err = b.Units(); err != nil {
+			return err
+		}
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "Boiler\tModel\tFirmware\tDate\tBoot\tCGI\tDisplay\tSIM\tBoard")
+	for _, u := range units {
+		bd, err := u.GetBoilerData()
+		if err != nil {
+			return err
+		}
+		vd, err := u.GetBoilerVersions()
+		if errors.Is(err, ibc.ErrUnsupported) {
+			// Older firmware only reports its own version.
+			vd.Firmware, _ = bd.Firmware()
+			vd.FirmwareDate = bd.FirmwareDate
+		} else if err != nil {
+			return err
+		}
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n", u.BoilerNum, bd.Model, vd.Firmware, vd.FirmwareDate, vd.Bootloader,
+			vd.CGI, vd.Display, vd.SIM, vd.Board)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	if !c.Capabilities {
+		return nil
+	}
+	for _, u := range units {
+		caps, err := u.ProbeCapabilities()
+		if err != nil {
+			return err
+		}
+		var unsupported []int
+		for req, ok := range caps.Requests {
+			if !ok {
+				unsupported = append(unsupported, req)
+			}
+		}
+		sort.Ints(unsupported)
+		fmt.Printf("Boiler %d firmware %s does not support requests: %v\n", u.BoilerNum, caps.Firmware, unsupported)
+	}
+	return nil
+}
@@ -18,6 +18,7 @@ var opts struct {
 	BoilerURL  string `short:"u" long:"url" description:"URL of the Boiler, ex -u \"http://192.168.10.2/\"" required:"true"`
 	OutputFile string `short:"f" long:"file" description:"File name/path of the output CSV file" required:"true"`
 	Interval   int    `short:"i" long:"interval" description:"The number of minutes to wait between log outputs." required:"true"`
+	Timeout    int    `long:"timeout" description:"The number of seconds to wait for the boiler to respond to each request." default:"30"`
 }
 var parser = flags.NewParser(&opts, flags.Default)
 
@@ -33,7 +34,7 @@ func main() {
 	}
 
 	// Open the boiler
-	b := ibc.Boiler{BaseURL: opts.BoilerURL}
+	b := ibc.Boiler{BaseURL: opts.BoilerURL, Timeout: time.Duration(opts.Timeout) * time.Second}
 
 	// Open the file for writing
 	f, err := os.OpenFile(opts.OutputFile, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
@@ -71,6 +72,9 @@ func main() {
 			"deltaPressure",
 			"inletPressure",
 			"outletPressure",
+			"opStatusName",
+			"pumpStatus",
+			"servicingState",
 		}
 		w.Write(header)
 		w.Flush()
@@ -93,12 +97,12 @@ func main() {
 		}
 	}()
 
-	logData(w, b)
+	logData(ctx, w, b)
 	ticker := time.NewTicker(time.Duration(opts.Interval) * time.Minute)
 	for {
 		select {
 		case <-ticker.C:
-			logData(w, b)
+			logData(ctx, w, b)
 		case <-ctx.Done():
 			w.Flush()
 			f.Close()
@@ -107,9 +111,9 @@ func main() {
 	}
 }
 
-func logData(w *csv.Writer, b ibc.Boiler) {
+func logData(ctx context.Context, w *csv.Writer, b ibc.Boiler) {
 
-	bedd, err := b.GetBoilerExtDetailData()
+	bedd, err := b.GetBoilerExtDetailDataContext(ctx)
 	if err != nil {
 		log.Println(err)
 		return
@@ -132,23 +136,26 @@ func logData(w *csv.Writer, b ibc.Boiler) {
 		bedd.Errors,
 		bedd.Warnings,
 		strings.Join(servicingStrings, ","),
-		strconv.Itoa(bedd.AirTemp),
+		strconv.Itoa(int(bedd.AirTemp)),
 		strconv.Itoa(bedd.Cycles),
-		strconv.Itoa(bedd.IndoorTemp),
+		strconv.Itoa(int(bedd.IndoorTemp)),
 		strconv.Itoa(bedd.MBH),
-		strconv.Itoa(bedd.OpStatus),
-		strconv.Itoa(bedd.OutdoorTemp),
+		strconv.Itoa(int(bedd.OpStatus)),
+		strconv.Itoa(int(bedd.OutdoorTemp)),
 		strconv.Itoa(bedd.Pumps),
-		strconv.Itoa(bedd.ReturnTemp),
-		strconv.Itoa(bedd.SecondaryTemp),
+		strconv.Itoa(int(bedd.ReturnTemp)),
+		strconv.Itoa(int(bedd.SecondaryTemp)),
 		strconv.Itoa(bedd.Servicing),
-		strconv.Itoa(bedd.StackTemp),
-		strconv.Itoa(bedd.SupplyTemp),
-		strconv.Itoa(bedd.TankTemp),
-		strconv.Itoa(bedd.TargetTemp),
-		strconv.FormatFloat(bedd.DeltaPressure, 'f', 2, 64),
-		strconv.FormatFloat(bedd.InletPressure, 'f', 2, 64),
-		strconv.FormatFloat(bedd.OutletPressure, 'f', 2, 64),
+		strconv.Itoa(int(bedd.StackTemp)),
+		strconv.Itoa(int(bedd.SupplyTemp)),
+		strconv.Itoa(int(bedd.TankTemp)),
+		strconv.Itoa(int(bedd.TargetTemp)),
+		strconv.FormatFloat(bedd.DeltaPressure.PSI(), 'f', 2, 64),
+		strconv.FormatFloat(bedd.InletPressure.PSI(), 'f', 2, 64),
+		strconv.FormatFloat(bedd.OutletPressure.PSI(), 'f', 2, 64),
+		bedd.OpStatus.String(),
+		bedd.PumpStatus().String(),
+		bedd.ServicingState().String(),
 	})
 	w.Flush()
 }
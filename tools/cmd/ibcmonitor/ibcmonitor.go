@@ -5,6 +5,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"html/template"
 	"io"
@@ -26,18 +27,29 @@ var statusTemplateHTML = `<div>
 Boiler Model:  {{.boilerData.Model}}<br/>
 Firmware:      {{.boilerData.FirmwareVersion}} {{.boilerData.FirmwareDate}}<br/>
 Boiler Status: {{.extDetail.Status}}<br/>
-Boiler Status: {{.boilerData.Status}}<br/>
+System Status: {{.boilerData.Status}}<br/>
 Errors:        {{.extDetail.Errors}}<br/>
+Faults:        {{.faults}}<br/>
 Warnings:      {{.extDetail.Warnings}}<br/>
-Supply Temp:   {{TempAsF .extDetail.SupplyTemp}}F<br/>
-Return Temp:   {{TempAsF .extDetail.ReturnTemp}}F<br/>
-DWH Tank Temp: {{TempAsF .extDetail.TankTemp}}F<br/>
+Warning Flags: {{.extDetail.ActiveWarnings}}<br/>
+Operation:     {{.extDetail.OpStatus}}<br/>
+Pumps:         {{.extDetail.PumpStatus}}<br/>
+Supply Temp:   {{.extDetail.SupplyTemp.Format .units}}<br/>
+Return Temp:   {{.extDetail.ReturnTemp.Format .units}}<br/>
+DWH Tank Temp: {{.extDetail.TankTemp.Format .units}}<br/>
 Cycles:        {{.extDetail.Cycles}}<br/>
-Servicing:     {{range $index, $element := .extDetail.ServicingLoadNumbers}}{{if $index}},{{end}}{{$element}}{{end}}<br/>
+Servicing:     {{if .extDetail.ServicingState}}{{.extDetail.ServicingState}}{{else}}{{range $index, $element := .extDetail.ServicingLoadNumbers}}{{if $index}},{{end}}{{$element}}{{end}}{{end}}<br/>
 Calling:       {{range $index, $element := .extDetail.CallingLoadNumbers}}{{if $index}},{{end}}{{$element}}{{end}}<br/>
 Circulating:   {{range $index, $element := .extDetail.CirculatingLoadNumbers}}{{if $index}},{{end}}{{$element}}{{end}}<br/>
 </div>`
 
+var alertsTemplateHTML = `<div>
+<h2>Boiler Alerts</h2>
+Boiler Alerts: {{if .ad.Enabled}}Enabled{{else}}Disabled{{end}}<br/>
+Active Alerts: {{.ad.ActiveAlerts}}<br/>
+{{range .recent}}{{.Time.Format "2006-01-02 15:04"}} {{.Condition}}{{if not .Sent}} (not sent){{end}}<br/>
+{{end}}</div>`
+
 var loadStatusTemplateHTML = `<div>
 <h2>Load {{.LoadNum}} Status</h2>
 Load Type: {{.lsd.LoadTypeName}}<br/>
@@ -72,8 +84,12 @@ Load 4: {{index . 5}}<br/>
 var b ibc.Boiler
 var lastDateRecorded int
 var lastEmailSent time.Time
+var lastClockAlertDay int
 var fileRowLength = 2 * (6 + (3 * 5)) // Assume 2 bytes per Char, Date + 2 digits and comma for total cycles pluse each load.
 
+// dstTolerance is how close to an hour the clock drift must be to be reported as a missed daylight saving change.
+const dstTolerance = 5 * time.Minute
+
 type webHookStatsBody struct {
 	Date        string `json:"date"`
 	Load1Cycles int    `json:"load1cycles"`
@@ -99,6 +115,8 @@ var opts struct {
 	AlertOnStartup    bool     `long:"alertOnStart" description:"Send an email and/or webhook on startup when this flag is present."`
 	AlertWebhookURL   string   `long:"alertURL" description:"Post a JSON message to a webhook URL on each Alert."`
 	StatsWebhookURL   string   `long:"statsURL" description:"Post a JSON message to a webhook URL each day with Stats."`
+	Timeout           int      `long:"timeout" description:"The number of seconds to wait for the boiler to respond to each request." default:"30"`
+	ClockDrift        int      `long:"clockDriftMinutes" description:"Send an alert when the boiler clock differs from this host by more than this many minutes. Disabled if 0."`
 }
 var parser = flags.NewParser(&opts, flags.Default)
 
@@ -121,7 +139,7 @@ func main() {
 		log.Println("Webhook Alert URL not specified.  Webhook Alerts disabled.")
 	}
 
-	b = ibc.Boiler{BaseURL: opts.BoilerURL}
+	b = ibc.Boiler{BaseURL: opts.BoilerURL, Timeout: time.Duration(opts.Timeout) * time.Second}
 
 	ctx := context.Background()
 	ctx, cancel := context.WithCancel(ctx)
@@ -150,31 +168,32 @@ func monitor(ctx context.Context) {
 
 	ticker := time.NewTicker(5 * time.Minute)
 	t := time.Now()
-	recordDailyCycles(t)
+	recordDailyCycles(ctx, t)
 	if opts.AlertOnStartup {
-		data, err := b.GetBoilerData()
+		data, err := b.GetBoilerDataContext(ctx)
 		if err != nil {
 			log.Fatalln("Error getting data from IBC Boiler", err)
 		}
-		emailStatus(data)
-		sendAlertWebhook(data, true)
+		emailStatus(ctx, data)
+		sendAlertWebhook(ctx, data, true)
 	} else {
-		checkErrors()
+		checkErrors(ctx)
 	}
 
 	log.Println("Monitoring...")
 	for {
 		select {
 		case t = <-ticker.C:
-			recordDailyCycles(t)
-			checkErrors()
+			recordDailyCycles(ctx, t)
+			checkErrors(ctx)
+			checkClock(ctx, t)
 		case <-ctx.Done():
 			return
 		}
 	}
 }
 
-func recordDailyCycles(t time.Time) {
+func recordDailyCycles(ctx context.Context, t time.Time) {
 	sendWeekly := false
 	// Check to see if we should record a new daily log. Record only once after 11:50p each day.
 	if t.After(time.Date(t.Year(), t.Month(), t.Day(), 23, 50, 0, 0, t.Location())) && t.YearDay() != lastDateRecorded {
@@ -182,15 +201,23 @@ func recordDailyCycles(t time.Time) {
 		lastDateRecorded = t.YearDay()
 		sendWeekly = t.Weekday() == time.Saturday
 
-		bedd, err := b.GetBoilerExtDetailData()
+		bedd, err := b.GetBoilerExtDetailDataContext(ctx)
 		if err != nil {
-			log.Println(err)
+			logBoilerError(err)
 			return
 		}
 
-		lsd, err := b.GetLoadStatusData()
-		if err != nil {
-			log.Println(err)
+		// Loads that could not be read are left blank rather than recorded as zero cycles.
+		loadCycles := []string{"0", "0", "0", "0"}
+		lsd, err := b.GetLoadStatusDataContext(ctx)
+		var loadErrs ibc.LoadErrors
+		if errors.As(err, &loadErrs) {
+			log.Println("Recording partial daily cycles:", err)
+			for _, n := range loadErrs.Loads() {
+				loadCycles[n-1] = ""
+			}
+		} else if err != nil {
+			logBoilerError(err)
 			return
 		}
 
@@ -198,15 +225,10 @@ func recordDailyCycles(t time.Time) {
 			sendStatsWebhook(lsd)
 		}
 
-		out := fmt.Sprintf("%s,%d", t.Format("2006-01-02"), bedd.Cycles)
-		loadCycles := make([]int, 4)
-		for i := range loadCycles {
-			if i < len(lsd) {
-				out = fmt.Sprintf("%v,%d", out, lsd[i].Cycles)
-			} else {
-				out = fmt.Sprintf("%v,0", out)
-			}
+		for _, l := range lsd {
+			loadCycles[l.LoadNumber()-1] = strconv.Itoa(l.Cycles)
 		}
+		out := fmt.Sprintf("%s,%d,%s", t.Format("2006-01-02"), bedd.Cycles, strings.Join(loadCycles, ","))
 
 		f, err := os.OpenFile(opts.DailyLogFile, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
 		if err != nil {
@@ -325,27 +347,67 @@ func sendWeeklySummary() {
 	emailResult("Weekly Boiler Summary", emailBuf.String())
 }
 
-func checkErrors() {
-	boilerData, err := b.GetBoilerData()
+func checkErrors(ctx context.Context) {
+	boilerData, err := b.GetBoilerDataContext(ctx)
 	if err != nil {
-		fmt.Println("Error retrieving data: ", err)
+		logBoilerError(err)
 		return
 	}
-	if (boilerData.Status != ibc.Standby &&
-		boilerData.Status != ibc.Purging &&
-		boilerData.Status != ibc.Igniting &&
-		boilerData.Status != ibc.Heating &&
-		boilerData.Status != ibc.Circulating &&
-		boilerData.Status != ibc.Initializing) ||
-		(boilerData.Warnings > 0 && !opts.IgnoreWarnings) {
+	if boilerData.Status.IsFault() || (boilerData.Warnings > 0 && !opts.IgnoreWarnings) {
 		if time.Now().After(lastEmailSent.Add(time.Duration(opts.EmailMuteDuration) * time.Minute)) {
-			emailStatus(boilerData)
-			sendAlertWebhook(boilerData, false)
+			emailStatus(ctx, boilerData)
+			sendAlertWebhook(ctx, boilerData, false)
 		}
 	}
 }
 
-func emailStatus(boilerData ibc.BoilerData) {
+// checkClock sends an alert, at most once a day, when the boiler clock has drifted from the host clock.
+func checkClock(ctx context.Context, t time.Time) {
+	if opts.ClockDrift <= 0 || t.YearDay() == lastClockAlertDay {
+		return
+	}
+
+	drift, err := b.CheckClockContext(ctx)
+	if err != nil {
+		logBoilerError(err)
+		return
+	}
+
+	threshold := time.Duration(opts.ClockDrift) * time.Minute
+	if !drift.Exceeds(threshold) {
+		return
+	}
+	lastClockAlertDay = t.YearDay()
+
+	msg := fmt.Sprintf("The boiler clock reads %s but this host reads %s, a difference of %s.",
+		drift.Boiler.Format(time.RFC1123), drift.Host.Format(time.RFC1123), drift.Drift.Round(time.Second))
+	if drift.DSTMismatch(dstTolerance) {
+		msg += " The boiler clock appears not to have been adjusted for daylight saving time."
+	}
+	log.Println(msg)
+	if opts.EmailServer != "" {
+		emailResult("Boiler Clock Drift", "<body><div>"+template.HTMLEscapeString(msg)+"</div></body>")
+	}
+}
+
+// logBoilerError logs an error returned by the boiler, separating an unreachable boiler from unexpected responses.
+func logBoilerError(err error) {
+	var transportErr *ibc.TransportError
+	var statusErr *ibc.HTTPStatusError
+	var decodeErr *ibc.DecodeError
+	switch {
+	case errors.As(err, &transportErr):
+		log.Println("Boiler offline or unreachable:", err)
+	case errors.As(err, &statusErr):
+		log.Printf("Boiler returned an error status %v: %s\n", statusErr.Status, statusErr.Body)
+	case errors.As(err, &decodeErr):
+		log.Printf("Boiler returned an unexpected response: %v: %s\n", decodeErr.Err, decodeErr.Body)
+	default:
+		log.Println("Error retrieving data: ", err)
+	}
+}
+
+func emailStatus(ctx context.Context, boilerData ibc.BoilerData) {
 	if opts.EmailServer == "" {
 		return
 	}
@@ -353,25 +415,40 @@ func emailStatus(boilerData ibc.BoilerData) {
 	emailBuf := new(bytes.Buffer)
 	emailBuf.WriteString("<body>")
 
-	extDetail, err := b.GetBoilerExtDetailData()
+	extDetail, err := b.GetBoilerExtDetailDataContext(ctx)
 	if err != nil {
-		log.Println("Error retrieving data: ", err)
+		logBoilerError(err)
 		return
 	}
 
 	tmplOpts := make(map[string]interface{})
 	tmplOpts["boilerData"] = boilerData
 	tmplOpts["extDetail"] = extDetail
+	tmplOpts["units"] = boilerData.Units()
+	tmplOpts["faults"] = boilerData.FaultTable().Decode(extDetail.MinorError, extDetail.MajorError, extDetail.SystemError)
 	executeTemplate(statusTemplateHTML, tmplOpts, emailBuf)
 
-	lsdSlice, err := b.GetLoadStatusData()
-	if err != nil {
-		log.Println("Error retrieving data: ", err)
+	// Show the boiler's own alert state alongside ours. Older firmware does not report it.
+	alertData, err := b.GetAlertDataContext(ctx)
+	if err == nil {
+		tmplOpts["ad"] = alertData
+		tmplOpts["recent"] = alertData.RecentAlerts(b.Location)
+		executeTemplate(alertsTemplateHTML, tmplOpts, emailBuf)
+	} else if !errors.Is(err, ibc.ErrUnsupported) {
+		logBoilerError(err)
+	}
+
+	lsdSlice, err := b.GetLoadStatusDataContext(ctx)
+	var loadErrs ibc.LoadErrors
+	if errors.As(err, &loadErrs) {
+		log.Println("Some loads could not be read:", err)
+	} else if err != nil {
+		logBoilerError(err)
 		return
 	}
 	for _, lsd := range lsdSlice {
 		tmplOpts = make(map[string]interface{})
-		tmplOpts["LoadNum"] = lsd.Load + 1
+		tmplOpts["LoadNum"] = lsd.LoadNumber()
 		tmplOpts["lsd"] = lsd
 		executeTemplate(loadStatusTemplateHTML, tmplOpts, emailBuf)
 	}
@@ -381,10 +458,7 @@ func emailStatus(boilerData ibc.BoilerData) {
 }
 
 func executeTemplate(templateBody string, data interface{}, w io.Writer) {
-	funcMap := template.FuncMap{
-		"TempAsF": b.TempAsF,
-	}
-	tmpl := template.New("").Funcs(funcMap)
+	tmpl := template.New("")
 	tmpl = template.Must(tmpl.Parse(templateBody))
 
 	err := tmpl.Execute(w, data)
@@ -412,19 +486,25 @@ func emailResult(subject string, body string) {
 func sendStatsWebhook(lsd []ibc.LoadStatusData) {
 
 	bodyJSON := &webHookStatsBody{
-		Date:        time.Now().Format("2006-01-02"),
-		Load1Cycles: lsd[0].Cycles,
-		Load2Cycles: lsd[1].Cycles,
+		Date: time.Now().Format("2006-01-02"),
+	}
+	for _, l := range lsd {
+		switch l.LoadNumber() {
+		case 1:
+			bodyJSON.Load1Cycles = l.Cycles
+		case 2:
+			bodyJSON.Load2Cycles = l.Cycles
+		}
 	}
 
 	postWebHook(bodyJSON, opts.StatsWebhookURL)
 }
 
-func sendAlertWebhook(boilerData ibc.BoilerData, restart bool) {
+func sendAlertWebhook(ctx context.Context, boilerData ibc.BoilerData, restart bool) {
 
-	extDetail, err := b.GetBoilerExtDetailData()
+	extDetail, err := b.GetBoilerExtDetailDataContext(ctx)
 	if err != nil {
-		log.Println("Error retrieving data: ", err)
+		logBoilerError(err)
 		return
 	}
 
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"errors"
 	"fmt"
 	"io"
 	"os"
@@ -13,13 +14,18 @@ import (
 var statusTemplateConsole = `Boiler Model:  {{.boilerData.Model}}
 Firmware:      {{.boilerData.FirmwareVersion}} {{.boilerData.FirmwareDate}}
 Boiler Status: {{.extDetail.Status}}
+System Status: {{.boilerData.Status}}
 Errors:        {{.extDetail.Errors}}
+Faults:        {{.faults}}
 Warnings:      {{.extDetail.Warnings}}
-Supply Temp:   {{TempAsF .extDetail.SupplyTemp}}F
-Return Temp:   {{TempAsF .extDetail.ReturnTemp}}F
-DWH Tank Temp: {{TempAsF .extDetail.TankTemp}}F
+Warning Flags: {{.extDetail.ActiveWarnings}}
+Operation:     {{.extDetail.OpStatus}}
+Pumps:         {{.extDetail.PumpStatus}}
+Supply Temp:   {{.extDetail.SupplyTemp.Format .units}}
+Return Temp:   {{.extDetail.ReturnTemp.Format .units}}
+DWH Tank Temp: {{.extDetail.TankTemp.Format .units}}
 Cycles:        {{.extDetail.Cycles}}
-Servicing:     {{range $index, $element := .extDetail.ServicingLoadNumbers}}{{if $index}},{{end}}{{$element}}{{end}}
+Servicing:     {{if .extDetail.ServicingState}}{{.extDetail.ServicingState}}{{else}}{{range $index, $element := .extDetail.ServicingLoadNumbers}}{{if $index}},{{end}}{{$element}}{{end}}{{end}}
 Calling:       {{range $index, $element := .extDetail.CallingLoadNumbers}}{{if $index}},{{end}}{{$element}}{{end}}
 Circulating:   {{range $index, $element := .extDetail.CirculatingLoadNumbers}}{{if $index}},{{end}}{{$element}}{{end}}
 
@@ -32,10 +38,18 @@ Load Cycles: {{.lsd.Cycles}}
 
 `
 
+var sensorsTemplateConsole = `Sensor        Installed  Raw   Reading
+{{range .sensors}}{{printf "%-13s" .Sensor.String}} {{printf "%-10t" .Installed}} {{printf "%-5d" .Raw}} {{.Format $.units}}
+{{end}}
+`
+
 var b ibc.Boiler
 
 var opts struct {
 	BoilerURL string `short:"u" long:"url" description:"URL of the Boiler, ex -u \"http://192.168.10.2/\"" required:"true"`
+	BoilerNum int    `short:"b" long:"boiler" description:"The number of the boiler on a cascade network to show." default:"0"`
+	All       bool   `short:"a" long:"all" description:"Show the status of every boiler on the cascade network."`
+	Sensors   bool   `short:"s" long:"sensors" description:"Show the raw reading and condition of each temperature sensor."`
 }
 var parser = flags.NewParser(&opts, flags.Default)
 
@@ -52,7 +66,20 @@ func main() {
 
 	b = ibc.Boiler{BaseURL: opts.BoilerURL}
 
-	showStatus(b)
+	if !opts.All {
+		showStatus(b.Unit(opts.BoilerNum))
+		return
+	}
+
+	units, err := b.Units()
+	if err != nil {
+		fmt.Println("Error retrieving data: ", err)
+		return
+	}
+	for _, u := range units {
+		fmt.Printf("Boiler Number: %d\n", u.BoilerNum)
+		showStatus(u)
+	}
 }
 
 func showStatus(b ibc.Boiler) {
@@ -71,26 +98,40 @@ func showStatus(b ibc.Boiler) {
 	tmplOpts := make(map[string]interface{})
 	tmplOpts["boilerData"] = boilerData
 	tmplOpts["extDetail"] = extDetail
+	tmplOpts["units"] = boilerData.Units()
+	tmplOpts["faults"] = boilerData.FaultTable().Decode(extDetail.MinorError, extDetail.MajorError, extDetail.SystemError)
 	executeTemplate(statusTemplateConsole, tmplOpts, os.Stdout)
 
+	if opts.Sensors {
+		tsd, err := b.GetBoilerTempSensorData()
+		if err != nil {
+			fmt.Println("Error retrieving sensor data: ", err)
+		} else {
+			tmplOpts["sensors"] = tsd.Sensors()
+			executeTemplate(sensorsTemplateConsole, tmplOpts, os.Stdout)
+		}
+	}
+
 	lsdSlice, err := b.GetLoadStatusData()
-	if err != nil {
+	var loadErrs ibc.LoadErrors
+	if errors.As(err, &loadErrs) {
+		for _, le := range loadErrs {
+			fmt.Printf("Error retrieving data for load %d: %v\n", le.Load, le.Err)
+		}
+	} else if err != nil {
 		fmt.Println("Error retrieving data: ", err)
 		return
 	}
 	for _, lsd := range lsdSlice {
 		tmplOpts = make(map[string]interface{})
-		tmplOpts["LoadNum"] = lsd.Load + 1
+		tmplOpts["LoadNum"] = lsd.LoadNumber()
 		tmplOpts["lsd"] = lsd
 		executeTemplate(loadStatusTemplateConsole, tmplOpts, os.Stdout)
 	}
 }
 
 func executeTemplate(templateBody string, data interface{}, w io.Writer) {
-	funcMap := template.FuncMap{
-		"TempAsF": b.TempAsF,
-	}
-	tmpl := template.New("").Funcs(funcMap)
+	tmpl := template.New("")
 	tmpl = template.Must(tmpl.Parse(templateBody))
 
 	err := tmpl.Execute(w, data)
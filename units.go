@@ -0,0 +1,135 @@
+package ibc
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Units selects the units temperatures and pressures are formatted in.
+type Units int
+
+// Unit System Constants
+const (
+	Metric   Units = 0
+	Imperial Units = 1
+)
+
+// Units returns the units the boiler is configured to display.
+func (bd BoilerData) Units() Units {
+	if bd.Imperial != 0 {
+		return Imperial
+	}
+	return Metric
+}
+
+// Temperature is a temperature as reported by the boiler, in quarter degrees Celsius.
+type Temperature int
+
+// C returns the temperature in degrees Celsius.
+func (t Temperature) C() float64 {
+	return float64(t) / 4
+}
+
+// F returns the temperature in degrees Fahrenheit.
+func (t Temperature) F() float64 {
+	return t.C()*9/5 + 32
+}
+
+// Format returns the temperature in the specified units, rounded to the nearest degree Fahrenheit
+// or half degree Celsius, for example "68°F" or "20.5°C".
+func (t Temperature) Format(u Units) string {
+	if u == Imperial {
+		return fmt.Sprintf("%.0f°F", math.Round(t.F()))
+	}
+	return fmt.Sprintf("%.1f°C", math.Round(t.C()*2)/2)
+}
+
+func (t Temperature) String() string {
+	return t.Format(Metric)
+}
+
+// TemperatureDelta is a difference between two temperatures as reported by the boiler, in quarter degrees Celsius.
+type TemperatureDelta int
+
+// C returns the difference in degrees Celsius.
+func (t TemperatureDelta) C() float64 {
+	return float64(t) / 4
+}
+
+// F returns the difference in degrees Fahrenheit.
+func (t TemperatureDelta) F() float64 {
+	return t.C() * 9 / 5
+}
+
+// Format returns the difference in the specified units, rounded the same way as Temperature.Format.
+func (t TemperatureDelta) Format(u Units) string {
+	if u == Imperial {
+		return fmt.Sprintf("%.0f°F", math.Round(t.F()))
+	}
+	return fmt.Sprintf("%.1f°C", math.Round(t.C()*2)/2)
+}
+
+func (t TemperatureDelta) String() string {
+	return t.Format(Metric)
+}
+
+const kPaPerPSI = 6.894757
+
+// Pressure is a pressure as reported by the boiler, in pounds per square inch.
+type Pressure float64
+
+// PSI returns the pressure in pounds per square inch.
+func (p Pressure) PSI() float64 {
+	return float64(p)
+}
+
+// KPa returns the pressure in kilopascals.
+func (p Pressure) KPa() float64 {
+	return float64(p) * kPaPerPSI
+}
+
+// Format returns the pressure in the specified units, for example "12.3 psi" or "84.8 kPa".
+func (p Pressure) Format(u Units) string {
+	if u == Imperial {
+		return fmt.Sprintf("%.1f psi", p.PSI())
+	}
+	return fmt.Sprintf("%.1f kPa", p.KPa())
+}
+
+func (p Pressure) String() string {
+	return p.Format(Imperial)
+}
+
+// TemperatureFromC returns the Temperature for the specified degrees Celsius, rounded to the nearest quarter degree.
+func TemperatureFromC(c float64) Temperature {
+	return Temperature(math.Round(c * 4))
+}
+
+// TemperatureFromF returns the Temperature for the specified degrees Fahrenheit, rounded to the nearest quarter degree Celsius.
+func TemperatureFromF(f float64) Temperature {
+	return TemperatureFromC((f - 32) * 5 / 9)
+}
+
+// ParseTemperature parses a temperature with a unit suffix, such as "180F", "82.5C" or "180°F".
+func ParseTemperature(s string) (Temperature, error) {
+	s = strings.ToUpper(strings.TrimSpace(s))
+	invalid := fmt.Errorf("ibc: invalid temperature %q, must be a number followed by C or F", s)
+	if s == "" {
+		return 0, invalid
+	}
+
+	unit := s[len(s)-1:]
+	v, err := strconv.ParseFloat(strings.TrimSpace(strings.TrimSuffix(s[:len(s)-1], "°")), 64)
+	if err != nil {
+		return 0, invalid
+	}
+	switch unit {
+	case "C":
+		return TemperatureFromC(v), nil
+	case "F":
+		return TemperatureFromF(v), nil
+	}
+	return 0, invalid
+}
@@ -0,0 +1,97 @@
+package ibc
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+)
+
+func TestTemperature(t *testing.T) {
+	tests := []struct {
+		temp             Temperature
+		c, f             float64
+		metric, imperial string
+	}{
+		{0, 0, 32, "0.0°C", "32°F"},
+		{80, 20, 68, "20.0°C", "68°F"},
+		{283, 70.75, 159.35, "71.0°C", "159°F"},
+		// 1.25°C is 34.25°F, which truncating integer math reported as 33°F.
+		{5, 1.25, 34.25, "1.5°C", "34°F"},
+		{-40, -10, 14, "-10.0°C", "14°F"},
+	}
+	for _, tt := range tests {
+		if math.Abs(tt.temp.C()-tt.c) > 1e-9 || math.Abs(tt.temp.F()-tt.f) > 1e-9 {
+			t.Errorf("Temperature(%d) is incorrect, got: %v°C %v°F, want: %v°C %v°F.", int(tt.temp), tt.temp.C(), tt.temp.F(), tt.c, tt.f)
+		}
+		if tt.temp.Format(Metric) != tt.metric || tt.temp.Format(Imperial) != tt.imperial {
+			t.Errorf("Temperature(%d).Format is incorrect, got: %s %s, want: %s %s.", int(tt.temp), tt.temp.Format(Metric), tt.temp.Format(Imperial), tt.metric, tt.imperial)
+		}
+	}
+
+	var b Boiler
+	if f := b.TempAsF(283); f != 159 {
+		t.Errorf("TempAsF is incorrect, got: %d, want: 159.", f)
+	}
+}
+
+func TestTemperatureDelta(t *testing.T) {
+	d := TemperatureDelta(40)
+	if d.C() != 10 || d.F() != 18 || d.Format(Imperial) != "18°F" {
+		t.Errorf("TemperatureDelta is incorrect, got: %v°C %v°F %s, want: 10°C 18°F.", d.C(), d.F(), d.Format(Imperial))
+	}
+}
+
+func TestPressure(t *testing.T) {
+	p := Pressure(12.5)
+	if p.PSI() != 12.5 || math.Abs(p.KPa()-86.1845) > 1e-3 {
+		t.Errorf("Pressure is incorrect, got: %v psi %v kPa, want: 12.5 psi 86.18 kPa.", p.PSI(), p.KPa())
+	}
+	if p.Format(Imperial) != "12.5 psi" || p.Format(Metric) != "86.2 kPa" {
+		t.Errorf("Pressure.Format is incorrect, got: %s %s.", p.Format(Imperial), p.Format(Metric))
+	}
+}
+
+func TestUnitsInData(t *testing.T) {
+	var bedd BoilerExtDetailData
+	err := json.Unmarshal([]byte(`{"SupplyT":283,"OutdoorT":-40,"InletPressure":14.2}`), &bedd)
+	if err != nil {
+		t.Fatal(err)
+	}
+	bd := BoilerData{Imperial: 1}
+	if s := bedd.SupplyTemp.Format(bd.Units()); s != "159°F" {
+		t.Errorf("SupplyTemp is incorrect, got: %s, want: 159°F.", s)
+	}
+	if s := bedd.OutdoorTemp.Format(bd.Units()); s != "14°F" {
+		t.Errorf("OutdoorTemp is incorrect, got: %s, want: 14°F.", s)
+	}
+	if s := bedd.InletPressure.Format(bd.Units()); s != "14.2 psi" {
+		t.Errorf("InletPressure is incorrect, got: %s, want: 14.2 psi.", s)
+	}
+
+	var beld BoilerErrorLogData
+	var bfd BoilerFactoryData
+	if err := json.Unmarshal([]byte(`{"DiffPressure":2.5,"InletPressure":14.2,"OutletPressure":11.7}`), &beld); err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal([]byte(`{"InletP":14.2,"OutletP":11.7,"DeltaP":2.5}`), &bfd); err != nil {
+		t.Fatal(err)
+	}
+	if beld.DiffPressure.PSI() != 2.5 || beld.OutletPressure != bfd.OutletP || bfd.DeltaP.Format(bd.Units()) != "2.5 psi" {
+		t.Errorf("Error log and factory pressures are incorrect, got: %+v %+v.", beld, bfd)
+	}
+}
+
+func TestParseTemperature(t *testing.T) {
+	tests := map[string]Temperature{"180F": 329, "82.5C": 330, "180°F": 329, " -10 f": -93, "0C": 0}
+	for s, want := range tests {
+		got, err := ParseTemperature(s)
+		if err != nil || got != want {
+			t.Errorf("ParseTemperature(%q) is incorrect, got: %d %v, want: %d.", s, int(got), err, int(want))
+		}
+	}
+	for _, s := range []string{"", "180", "F", "hot"} {
+		if _, err := ParseTemperature(s); err == nil {
+			t.Errorf("ParseTemperature(%q) returned no error.", s)
+		}
+	}
+}
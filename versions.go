@@ -0,0 +1,241 @@
+package ibc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Version is a parsed firmware or board version, ex "2.10" or "V3.4.1b".
+type Version struct {
+	Major, Minor, Patch int
+	// Suffix is any text following the numbers, ex "b" in "3.4.1b".
+	Suffix string
+	// Raw is the version as reported by the boiler.
+	Raw string
+}
+
+// ParseVersion parses a version of up to three dot separated numbers, optionally prefixed with "V" or "R" and
+// followed by a suffix.
+func ParseVersion(s string) (Version, error) {
+	v := Version{Raw: s}
+	rest := strings.TrimLeft(strings.TrimSpace(s), "vVrR")
+	parts := []*int{&v.Major, &v.Minor, &v.Patch}
+	for i, p := range parts {
+		end := strings.IndexFunc(rest, func(r rune) bool { return !unicode.IsDigit(r) })
+		if end == -1 {
+			end = len(rest)
+		}
+		if end == 0 {
+			if i == 0 {
+				return v, fmt.Errorf("ibc: invalid version %q", s)
+			}
+			break
+		}
+		*p, _ = strconv.Atoi(rest[:end])
+		rest = rest[end:]
+		if i == len(parts)-1 || !strings.HasPrefix(rest, ".") || len(rest) < 2 || !unicode.IsDigit(rune(rest[1])) {
+			break
+		}
+		rest = rest[1:]
+	}
+	v.Suffix = strings.TrimSpace(rest)
+	return v, nil
+}
+
+// MustParseVersion is like ParseVersion but panics if the version cannot be parsed. It is intended for constants.
+func MustParseVersion(s string) Version {
+	v, err := ParseVersion(s)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Compare returns -1, 0 or 1 if v is older than, the same as, or newer than o. Suffixes are compared as text,
+// and a version without a suffix is older than the same version with one.
+func (v Version) Compare(o Version) int {
+	for _, d := range [...]int{v.Major - o.Major, v.Minor - o.Minor, v.Patch - o.Patch, strings.Compare(v.Suffix, o.Suffix)} {
+		if d < 0 {
+			return -1
+		}
+		if d > 0 {
+			return 1
+		}
+	}
+	return 0
+}
+
+// AtLeast returns true if v is the same as or newer than o.
+func (v Version) AtLeast(o Version) bool {
+	return v.Compare(o) >= 0
+}
+
+// String returns the version as reported by the boiler, or an empty string for the zero Version.
+func (v Version) String() string {
+	if v.Raw != "" || v == (Version{}) {
+		return v.Raw
+	}
+	s := fmt.Sprintf("%d.%d", v.Major, v.Minor)
+	if v.Patch != 0 {
+		s += fmt.Sprintf(".%d", v.Patch)
+	}
+	return s + v.Suffix
+}
+
+// MarshalJSON encodes the Version as the text reported by the boiler.
+func (v Version) MarshalJSON() ([]byte, error) {
+	return json.Marshal(v.String())
+}
+
+// UnmarshalJSON decodes a Version from its text. Text that cannot be parsed is kept in Raw with a zero version,
+// so one unexpected version does not prevent the rest of a response from being read.
+func (v *Version) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("ibc: invalid version %s", data)
+	}
+	parsed, err := ParseVersion(s)
+	if err != nil {
+		parsed = Version{Raw: s}
+	}
+	*v = parsed
+	return nil
+}
+
+// Firmware returns the parsed firmware version of the boiler.
+func (bd BoilerData) Firmware() (Version, error) {
+	return ParseVersion(bd.FirmwareVersion)
+}
+
+// BoilerVersionsData represents the data returned by the ReqBoilerVersions request.
+type BoilerVersionsData struct {
+	//"rbid": 0
+	//"object_no": 35
+	Firmware     Version `json:"FWVersion"`
+	FirmwareDate string  `json:"FWDate"`
+	Bootloader   Version `json:"BootVersion"`
+	CGI          Version `json:"CGIVersion"`
+	Display      Version `json:"LCDVersion"`
+	SIM          Version `json:"SIMVersion"`
+	Board        Version `json:"BoardRev"`
+}
+
+// GetBoilerVersions returns the BoilerVersionsData for the current boiler.
+func (b Boiler) GetBoilerVersions() (BoilerVersionsData, error) {
+	return b.GetBoilerVersionsContext(context.Background())
+}
+
+// GetBoilerVersionsContext returns the BoilerVersionsData for the current boiler using the provided context.
+func (b Boiler) GetBoilerVersionsContext(ctx context.Context) (BoilerVersionsData, error) {
+	reqObj := requestObject{ObjectNum: 100, ObjectRequest: ReqBoilerVersions, BoilerNum: b.BoilerNum}
+	var respObj = BoilerVersionsData{}
+	return respObj, b.getData(ctx, reqObj, &respObj)
+}
+
+// Capabilities records which requests, and which fields of each, a probed boiler's firmware supports. They are
+// not derived from the firmware version, so each boiler must be probed. See ProbeCapabilities.
+type Capabilities struct {
+	Firmware Version
+	// Requests records whether each probed request is supported.
+	Requests map[int]bool
+	// Fields lists the fields returned by each supported request, sorted.
+	Fields map[int][]string
+}
+
+// Supports returns false if the request was probed and found to be unsupported. Requests that were not probed
+// are assumed to be supported.
+func (c *Capabilities) Supports(request int) bool {
+	supported, probed := c.Requests[request]
+	return supported || !probed
+}
+
+// HasField returns true if the request was probed and its response included field.
+func (c *Capabilities) HasField(request int, field string) bool {
+	fields := c.Fields[request]
+	i := sort.SearchStrings(fields, field)
+	return i < len(fields) && fields[i] == field
+}
+
+// probeRequests lists the requests checked by ProbeCapabilities. The error log, site log and capture requests are
+// not probed, as their first entry is empty when there is nothing logged, nor are the restore and password requests.
+// Per-load requests are probed for load 1.
+var probeRequests = []struct {
+	request int
+	perLoad bool
+}{
+	{ReqMasterBoilerData, false},
+	{ReqBoilerStatusData, false},
+	{ReqBoilerRunProfileData, false},
+	{ReqBoilerLogData, false},
+	{ReqBoilerData, false},
+	{ReqBoilerStandardData, false},
+	{ReqBoilerSetbackData, true},
+	{ReqBoilerAdvSetttingsData, false},
+	{ReqBoilerLoadSettingsData, true},
+	{ReqBoilerMultiSettingData, false},
+	{ReqBoilerCleaningSettingData, false},
+	{ReqBoilerExtDetailData, false},
+	{ReqBoilerFactoryData, false},
+	{ReqBoilerFactorySettingsData, false},
+	{ReqClockData, false},
+	{ReqLoadPairingData, false},
+	{ReqBoilerTempSensorData, false},
+	{ReqAlertData, false},
+	{ReqLoadStatusData, true},
+	{ReqBoilerSiteData, false},
+	{ReqBoilerVersions, false},
+	{ReqNetworkBoilerData, false},
+	{ReqAdvancedOptionsData, false},
+	{ReqBoilerSIMData, false},
+	{ReqSlaveMACADDRSData, false},
+	{ReqProgSetbackData, true},
+	{ReqInternetUpdateData, false},
+}
+
+// ProbeCapabilities requests each known object once to find which the boiler firmware supports and which fields
+// each returns. Requests that return an error matching ErrUnsupported, which is how requests added in later firmware
+// that earlier firmware answers with an empty object are reported, are recorded as unsupported. Any other error
+// stops the probe and is returned. Set the result as
+// Boiler.Capabilities to avoid querying unsupported requests again.
+func (b Boiler) ProbeCapabilities() (*Capabilities, error) {
+	return b.ProbeCapabilitiesContext(context.Background())
+}
+
+// ProbeCapabilitiesContext probes the requests the boiler firmware supports using the provided context. See ProbeCapabilities.
+func (b Boiler) ProbeCapabilitiesContext(ctx context.Context) (*Capabilities, error) {
+	b.Capabilities = nil
+	c := &Capabilities{Requests: make(map[int]bool), Fields: make(map[int][]string)}
+
+	bd, err := b.GetBoilerDataContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	c.Firmware, _ = bd.Firmware()
+
+	for _, pr := range probeRequests {
+		load := 0
+		if pr.perLoad {
+			load = 1
+		}
+		obj, err := b.getObject(ctx, pr.request, load, 0)
+		if errors.Is(err, ErrUnsupported) {
+			c.Requests[pr.request] = false
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("ibc: unable to probe request %d: %w", pr.request, err)
+		}
+		c.Requests[pr.request] = true
+		for field := range obj {
+			c.Fields[pr.request] = append(c.Fields[pr.request], field)
+		}
+		sort.Strings(c.Fields[pr.request])
+	}
+	return c, nil
+}
@@ -0,0 +1,159 @@
+package ibc
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestParseVersion(t *testing.T) {
+	tests := []struct {
+		in   string
+		want Version
+	}{
+		{"2.10", Version{Major: 2, Minor: 10, Raw: "2.10"}},
+		{"V3.4.1b", Version{Major: 3, Minor: 4, Patch: 1, Suffix: "b", Raw: "V3.4.1b"}},
+		{"R7", Version{Major: 7, Raw: "R7"}},
+	}
+	for _, test := range tests {
+		got, err := ParseVersion(test.in)
+		if err != nil || got != test.want {
+			t.Errorf("ParseVersion(%q) is incorrect, got: %+v %v, want: %+v.", test.in, got, err, test.want)
+		}
+	}
+	if _, err := ParseVersion("unknown"); err == nil {
+		t.Errorf("ParseVersion of text returned no error.")
+	}
+}
+
+func TestVersionCompare(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"2.9", "2.10", -1},
+		{"2.10", "2.10.0", 0},
+		{"3.0", "2.99.9", 1},
+		{"3.4.1", "3.4.1b", -1},
+	}
+	for _, test := range tests {
+		if got := MustParseVersion(test.a).Compare(MustParseVersion(test.b)); got != test.want {
+			t.Errorf("Compare(%s, %s) is incorrect, got: %v, want: %v.", test.a, test.b, got, test.want)
+		}
+	}
+	if !MustParseVersion("2.10").AtLeast(MustParseVersion("2.9")) {
+		t.Errorf("AtLeast is incorrect.")
+	}
+}
+
+func TestGetBoilerVersions(t *testing.T) {
+	b, done := newTestBoiler(func(req requestObject) (int, string) {
+		return http.StatusOK, `{"FWVersion":"2.10","FWDate":"Mar 2 2018","BootVersion":"1.1","CGIVersion":"4.2.7",` +
+			`"LCDVersion":"V1.03","SIMVersion":"unknown","BoardRev":"C"}`
+	})
+	defer done()
+
+	vd, err := b.GetBoilerVersions()
+	if err != nil {
+		t.Fatalf("GetBoilerVersions returned error: %v", err)
+	}
+	if vd.Firmware.Minor != 10 || vd.CGI.Patch != 7 || vd.Display.Minor != 3 {
+		t.Errorf("GetBoilerVersions is incorrect, got: %+v.", vd)
+	}
+	if vd.SIM.String() != "unknown" || vd.SIM.Major != 0 {
+		t.Errorf("Unparsable version is incorrect, got: %+v.", vd.SIM)
+	}
+}
+
+// capabilityObjects returns an object for each probed request from a boiler with firmware 1.8, which answers the
+// SIM, alert and programmable setback requests with an empty object as firmware that does not support them does.
+func capabilityObjects() []fakeObject {
+	objects := []fakeObject{{ReqBoilerData, 0, 0, `{"rbid":0,"object_no":11,"model":"SL 20-115 G3","fwversion":"1.8"}`}}
+	for _, pr := range probeRequests {
+		o := fakeObject{pr.request, 0, 0, fmt.Sprintf(`{"rbid":0,"object_no":%d,"Request":%d,"Load":0}`, pr.request, pr.request)}
+		if pr.perLoad {
+			o.load = 1
+		}
+		switch pr.request {
+		case ReqBoilerData:
+			continue
+		case ReqBoilerSIMData, ReqAlertData:
+			o.body = `{}`
+		case ReqProgSetbackData:
+			o.body = `{"rbid":0,"object_no":50}`
+		}
+		objects = append(objects, o)
+	}
+	return objects
+}
+
+func TestProbeCapabilities(t *testing.T) {
+	b, fb, done := newFakeBoiler(capabilityObjects()...)
+	defer done()
+
+	if _, err := b.GetBoilerSIMData(); !errors.Is(err, ErrUnsupported) {
+		t.Errorf("GetBoilerSIMData of an unsupported request is incorrect, got: %v, want: %v.", err, ErrUnsupported)
+	}
+
+	c, err := b.ProbeCapabilities()
+	if err != nil {
+		t.Fatalf("ProbeCapabilities returned error: %v", err)
+	}
+	if c.Firmware.Compare(MustParseVersion("1.8")) != 0 {
+		t.Errorf("Capabilities firmware is incorrect, got: %v.", c.Firmware)
+	}
+	for _, req := range []int{ReqBoilerSIMData, ReqAlertData, ReqProgSetbackData} {
+		if c.Supports(req) {
+			t.Errorf("Capabilities supports request %d.", req)
+		}
+	}
+	if !c.Supports(ReqBoilerTempSensorData) || !c.Supports(ReqPasswordData) {
+		t.Errorf("Capabilities does not support a supported request.")
+	}
+	if !c.HasField(ReqClockData, "Load") || c.HasField(ReqClockData, "Year") || c.HasField(ReqAlertData, "Load") {
+		t.Errorf("Capabilities fields are incorrect, got: %v.", c.Fields)
+	}
+
+	b.Capabilities = c
+	reads := fb.readCount()
+	if _, err := b.GetBoilerSIMData(); !errors.Is(err, ErrUnsupported) || fb.readCount() != reads {
+		t.Errorf("GetBoilerSIMData with Capabilities is incorrect, got: %v after %d requests.", err, fb.readCount()-reads)
+	}
+}
+
+func TestProbeCapabilitiesError(t *testing.T) {
+	b, fb, done := newFakeBoiler(capabilityObjects()...)
+	defer done()
+	fb.remove(ReqClockData, 0, 0)
+
+	_, err := b.ProbeCapabilities()
+	var statusErr *HTTPStatusError
+	if !errors.As(err, &statusErr) {
+		t.Errorf("ProbeCapabilities error is incorrect, got: %v, want: *HTTPStatusError.", err)
+	}
+}
+
+func TestEmptyObjectUnsupported(t *testing.T) {
+	b, fb, done := newFakeBoiler(capabilityObjects()...)
+	defer done()
+
+	// Only requests added in later firmware are unsupported when answered with an empty object.
+	fb.set(ReqBoilerStandardData, 0, 0, `{}`)
+	if _, err := b.GetBoilerStandardData(); err != nil {
+		t.Errorf("GetBoilerStandardData of an empty object is incorrect, got: %v, want: no error.", err)
+	}
+	if _, err := b.GetAlertData(); !errors.Is(err, ErrUnsupported) {
+		t.Errorf("GetAlertData of an empty object is incorrect, got: %v, want: %v.", err, ErrUnsupported)
+	}
+
+	// An object holding only the envelope keys is empty.
+	fb.set(ReqAlertData, 0, 0, `{"rbid":0,"object_no":31}`)
+	if _, err := b.GetAlertData(); !errors.Is(err, ErrUnsupported) {
+		t.Errorf("GetAlertData of an envelope is incorrect, got: %v, want: %v.", err, ErrUnsupported)
+	}
+	if _, err := b.GetProgSetbackData(1, time.Sunday); !errors.Is(err, ErrUnsupported) {
+		t.Errorf("GetProgSetbackData of an envelope is incorrect, got: %v, want: %v.", err, ErrUnsupported)
+	}
+}
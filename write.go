@@ -0,0 +1,65 @@
+package ibc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+)
+
+// ErrWritesDisabled is returned by methods that change boiler settings unless Boiler.AllowWrites is set.
+var ErrWritesDisabled = errors.New("ibc: writes are disabled, set Boiler.AllowWrites to change boiler settings")
+
+// WriteResult describes a write request made, or in dry run mode not made, to the boiler.
+type WriteResult struct {
+	Request int
+	// Object is the JSON request object sent to the boiler, or that would be sent in dry run mode.
+	Object string
+	// Sent is true if the request was sent to the boiler.
+	Sent bool
+	// Verified is true if the value read back from the boiler after the write matched the value written.
+	Verified bool
+}
+
+// writeData sends data to the boiler as the object for the specified request number. The boiler accepts a
+// write as the same object it returns when read, tagged with the boiler number and object number.
+// In dry run mode the request is built but not sent. Otherwise ErrWritesDisabled is returned unless writes are allowed.
+func (b Boiler) writeData(ctx context.Context, requestNumber int, data interface{}) (WriteResult, error) {
+	res := WriteResult{Request: requestNumber}
+	obj, err := writeObject(b.BoilerNum, requestNumber, data)
+	if err != nil {
+		return res, err
+	}
+	jsonBytes, err := json.Marshal(obj)
+	if err != nil {
+		return res, err
+	}
+	res.Object = string(jsonBytes)
+
+	if b.DryRun {
+		return res, nil
+	}
+	if !b.AllowWrites {
+		return res, ErrWritesDisabled
+	}
+
+	var respObj interface{}
+	if err := b.getData(ctx, obj, &respObj); err != nil {
+		return res, err
+	}
+	res.Sent = true
+	return res, nil
+}
+
+func writeObject(boilerNum int, requestNumber int, data interface{}) (map[string]interface{}, error) {
+	jsonBytes, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+	obj := make(map[string]interface{})
+	if err := json.Unmarshal(jsonBytes, &obj); err != nil {
+		return nil, err
+	}
+	obj["rbid"] = boilerNum
+	obj["object_no"] = requestNumber
+	return obj, nil
+}